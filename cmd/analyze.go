@@ -3,14 +3,17 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
 	"github.com/loickal/newsletter-cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
 	daysFlag   int
+	sinceFlag  string
 	emailFlag  string
 	serverFlag string
 )
@@ -43,10 +46,22 @@ You can also provide credentials via flags for non-interactive use.`,
 			server = account.Server
 		}
 
-		flagsProvided := daysFlag > 0 || emailFlag != "" || serverFlag != ""
+		flagsProvided := daysFlag > 0 || sinceFlag != "" || emailFlag != "" || serverFlag != ""
+
+		// --since takes precedence over --days
+		period := sinceFlag
+		if period == "" && daysFlag > 0 {
+			period = strconv.Itoa(daysFlag)
+		}
+		if period != "" {
+			if _, err := imap.ParseSearchWindow(period); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
 		currentVersion := getVersion()
-		if err := ui.RunAppSync(email, pass, server, daysFlag, flagsProvided, "analyze", currentVersion); err != nil {
+		if err := ui.RunAppSync(email, pass, server, period, flagsProvided, "analyze", currentVersion); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -55,6 +70,7 @@ You can also provide credentials via flags for non-interactive use.`,
 
 func init() {
 	analyzeCmd.Flags().IntVarP(&daysFlag, "days", "d", 30, "Number of days to analyze (default: 30)")
+	analyzeCmd.Flags().StringVar(&sinceFlag, "since", "", "Period to analyze: 90d, 2w, 3m, 1y or all (overrides --days)")
 	analyzeCmd.Flags().StringVarP(&emailFlag, "email", "e", "", "Email address (overrides saved credentials)")
 	analyzeCmd.Flags().StringVarP(&serverFlag, "server", "s", "", "IMAP server (overrides saved credentials)")
 	rootCmd.AddCommand(analyzeCmd)
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/unsubscribe"
+	"github.com/spf13/cobra"
+)
+
+var (
+	complaintToFlag     string
+	complaintBundleFlag string
+)
+
+var complaintCmd = &cobra.Command{
+	Use:   "complaint [sender]",
+	Short: "Draft a GDPR complaint for senders that ignore unsubscribes",
+	Long: `Check the selected account for mail from senders you unsubscribed from more
+than 30 days ago. Only unsubscribes the audit log records as successful for
+the account count, not imported or synced ones. Without a sender, lists the
+senders that kept mailing.
+
+With a sender, prints a pre-filled complaint to the sender's data protection
+officer, and with --bundle also writes a zip with the complaint, the audit
+log of your unsubscribe and the messages received since, ready to send to
+a data protection authority.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		account, err := config.GetSelectedAccount()
+		if err != nil || account == nil {
+			fmt.Fprintln(os.Stderr, "Error: no account selected, run `newsletter-cli login` first")
+			os.Exit(1)
+		}
+		password, err := config.Decrypt(account.Password)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to decrypt password: %v\n", err)
+			os.Exit(1)
+		}
+
+		var folders []string
+		if settings, err := config.LoadSettings(); err == nil {
+			folders = settings.Folders
+		}
+
+		sender := ""
+		if len(args) == 1 {
+			sender = args[0]
+		}
+
+		violations, err := unsubscribe.FindViolations(account.Server, account.Email, password, folders, sender, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if sender == "" {
+			if len(violations) == 0 {
+				fmt.Println("✅ No sender kept mailing after you unsubscribed")
+				return
+			}
+			fmt.Printf("⚖️  %d sender(s) kept mailing %s after you unsubscribed:\n\n", len(violations), account.Email)
+			for _, v := range violations {
+				fmt.Printf("  %-40s unsubscribed %s, %d message(s) since %s\n",
+					v.Sender, v.UnsubscribedAt.Format("2006-01-02"), len(v.Messages), v.Deadline.Format("2006-01-02"))
+			}
+			fmt.Println("\nRun `newsletter-cli complaint <sender>` to draft a complaint.")
+			return
+		}
+
+		if len(violations) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no mail from %s after the %d day deadline of an unsubscribe\n",
+				sender, int(unsubscribe.ComplaintDeadline.Hours()/24))
+			os.Exit(1)
+		}
+
+		v := violations[0]
+		complaint := unsubscribe.NewComplaint(v, complaintToFlag)
+		fmt.Printf("To: %s\nSubject: %s\n\n%s\n", complaint.To, complaint.Subject, complaint.Body)
+		if complaintToFlag == "" {
+			fmt.Println("💡 The recipient is a guess; the sender's privacy policy usually names its DPO (use --to).")
+		}
+		fmt.Printf("✉️  Open in your mail client: %s\n", complaint.MailtoLink())
+
+		if complaintBundleFlag != "" {
+			if err := unsubscribe.WriteEvidenceBundle(complaintBundleFlag, v, complaint); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ Evidence bundle written to %s\n", complaintBundleFlag)
+		}
+	},
+}
+
+func init() {
+	complaintCmd.Flags().StringVar(&complaintToFlag, "to", "", "Address of the sender's data protection officer")
+	complaintCmd.Flags().StringVar(&complaintBundleFlag, "bundle", "", "Write an evidence bundle (zip) to this path")
+	rootCmd.AddCommand(complaintCmd)
+}
@@ -0,0 +1,675 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage tool settings",
+}
+
+var exportProfileCmd = &cobra.Command{
+	Use:   "export-profile <file>",
+	Short: "Export settings to a shareable profile file (no secrets)",
+	Long: `Export all settings to a single profile file that can be imported on
+another machine or shared with colleagues. Accounts, passwords and premium
+tokens are never included.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.ExportProfile(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Profile exported to %s\n", args[0])
+	},
+}
+
+var importProfileCmd = &cobra.Command{
+	Use:   "import-profile <file>",
+	Short: "Import settings from a profile file",
+	Long: `Import settings from a profile file, replacing the current settings.
+The previous settings are backed up to settings.json.bak.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := config.ImportProfile(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Imported profile exported on %s\n", profile.ExportedAt.Format("2006-01-02"))
+
+		// Push the new settings if premium sync is enabled
+		if api.IsPremiumEnabled() {
+			_ = api.SyncSettingsToCloud()
+		}
+	},
+}
+
+var importUnsubscribedCmd = &cobra.Command{
+	Use:   "import-unsubscribed <file>",
+	Short: "Import unsubscribe history from Gmail or an ESP export",
+	Long: `Add the senders of an unsubscribe history export to your unsubscribed list,
+so newsletters you already left elsewhere show up as unsubscribed.
+
+Accepted are CSV files with a sender or email column, such as Google's
+subscription management data and the suppression exports of most email
+service providers, and JSON files with a list of addresses or of objects
+with such a field. An unsubscribe date is used when the file has one.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		entries, skipped, err := config.ParseUnsubscribeExport(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Suppression exports can list your own address, never import it
+		ownSkipped := 0
+		if accounts, err := config.GetAllAccounts(); err == nil {
+			own := make(map[string]bool, len(accounts))
+			for _, acc := range accounts {
+				own[strings.ToLower(acc.Email)] = true
+			}
+			filtered := entries[:0]
+			for _, e := range entries {
+				if own[e.Sender] {
+					ownSkipped++
+					continue
+				}
+				filtered = append(filtered, e)
+			}
+			entries = filtered
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		result, err := config.ImportUnsubscribed(entries, skipped, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if result.Skipped > 0 {
+			fmt.Printf("⚠️  Skipped %d row(s) without a usable address\n", result.Skipped)
+		}
+		if ownSkipped > 0 {
+			fmt.Printf("Skipped %d row(s) with one of your own addresses\n", ownSkipped)
+		}
+		if dryRun {
+			fmt.Printf("Would add %d of %d sender(s), %d already unsubscribed\n", result.Added, result.Found, result.Found-result.Added)
+			return
+		}
+		fmt.Printf("✅ Added %d of %d sender(s), %d already unsubscribed\n", result.Added, result.Found, result.Found-result.Added)
+
+		if result.Added > 0 && api.IsPremiumEnabled() {
+			_ = api.AutoSync()
+		}
+	},
+}
+
+var foldersCmd = &cobra.Command{
+	Use:   "folders [folder...]",
+	Short: "Show or set the IMAP folders to analyze",
+	Long: `Show the folders included in analysis, or replace them with the given list.
+Pass --reset to analyze INBOX only again.
+
+Example:
+  newsletter-cli config folders INBOX Archive "[Gmail]/All Mail"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		reset, _ := cmd.Flags().GetBool("reset")
+		if !reset && len(args) == 0 {
+			folders := settings.Folders
+			if len(folders) == 0 {
+				folders = []string{"INBOX"}
+			}
+			for _, f := range folders {
+				fmt.Println("• " + f)
+			}
+			return
+		}
+
+		settings.Folders = args
+		if reset {
+			settings.Folders = nil
+		}
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Analysis folders updated")
+	},
+}
+
+var categoryFeedbackCmd = &cobra.Command{
+	Use:   "category-feedback [on|off]",
+	Short: "Share manual category changes to improve categorization",
+	Long: `When enabled, categories you reassign in the dashboard ([c]) are sent to the
+enrichment service as feedback. Only the sender address and the categories
+are sent. Overrides are always applied locally, whether or not this is on.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			status := "off"
+			if settings.ShareCategoryFeedback {
+				status = "on"
+			}
+			fmt.Printf("Category feedback: %s (%d local override(s))\n", status, len(settings.CategoryOverrides))
+			return
+		}
+
+		switch args[0] {
+		case "on":
+			settings.ShareCategoryFeedback = true
+		case "off":
+			settings.ShareCategoryFeedback = false
+		default:
+			fmt.Fprintf(os.Stderr, "Error: expected on or off, got %q\n", args[0])
+			os.Exit(1)
+		}
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Category feedback turned %s\n", args[0])
+	},
+}
+
+var maxMessageSizeCmd = &cobra.Command{
+	Use:   "max-message-size [MB]",
+	Short: "Show or set the size above which message bodies are skipped",
+	Long: fmt.Sprintf(`Messages larger than this are still counted during analysis, but only their
+headers are downloaded, so large attachments don't slow the fetch down.
+Use 0 to restore the default (%d MB) or -1 to always download full messages.`, config.DefaultMaxMessageSizeMB),
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			if limit := settings.MaxMessageSize(); limit > 0 {
+				fmt.Printf("Max message size: %d MB\n", limit>>20)
+			} else {
+				fmt.Println("Max message size: no limit")
+			}
+			return
+		}
+
+		size, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid size %q\n", args[0])
+			os.Exit(1)
+		}
+		if size < 0 {
+			size = -1
+		}
+		settings.MaxMessageSizeMB = size
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Max message size updated")
+	},
+}
+
+var bodyScanCmd = &cobra.Command{
+	Use:   "body-scan [on|off]",
+	Short: "Show or set whether message bodies are scanned during analysis",
+	Long: `Analysis downloads the body of each newsletter to find preference center
+links and tracking pixels. Turn this off to download only message headers:
+the fetch is faster and reads less of your mail, but senders get no
+preference links or tracking badge.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			status := "on"
+			if settings.SkipBodyScan {
+				status = "off"
+			}
+			fmt.Printf("Body scanning: %s\n", status)
+			return
+		}
+
+		switch args[0] {
+		case "on":
+			settings.SkipBodyScan = false
+		case "off":
+			settings.SkipBodyScan = true
+		default:
+			fmt.Fprintf(os.Stderr, "Error: expected on or off, got %q\n", args[0])
+			os.Exit(1)
+		}
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Body scanning turned %s\n", args[0])
+	},
+}
+
+var noiseBudgetCmd = &cobra.Command{
+	Use:   "noise-budget [emails|off]",
+	Short: "Show or set the weekly newsletter noise budget",
+	Long: `Set the maximum number of newsletter emails you want to receive per week.
+While 'newsletter-cli watch' runs, it counts the week's newsletter volume and
+alerts you with a desktop notification and a banner in the app once the
+budget is exceeded, suggesting the senders worth cutting first.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			if settings.NoiseBudget <= 0 {
+				fmt.Println("Noise budget: off")
+				return
+			}
+			fmt.Printf("Noise budget: %d newsletter emails per week\n", settings.NoiseBudget)
+			if store, err := config.LoadNoise(); err == nil {
+				if week := store.Week(time.Now()); week != nil {
+					fmt.Printf("This week so far: %d\n", week.Total())
+				}
+			}
+			return
+		}
+
+		budget := 0
+		if args[0] != "off" {
+			budget, err = strconv.Atoi(args[0])
+			if err != nil || budget <= 0 {
+				fmt.Fprintf(os.Stderr, "Error: invalid budget %q, use a positive number or off\n", args[0])
+				os.Exit(1)
+			}
+		}
+		settings.NoiseBudget = budget
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if budget == 0 {
+			fmt.Println("✅ Noise budget disabled")
+		} else {
+			fmt.Printf("✅ Noise budget set to %d emails per week\n", budget)
+		}
+	},
+}
+
+var syncQueueMaxAgeCmd = &cobra.Command{
+	Use:   "sync-queue-max-age [days|forever]",
+	Short: "Show or set how long changes that failed to sync are retried",
+	Long: fmt.Sprintf(`Premium changes that could not be synced are queued and retried. Changes
+queued longer than this are dropped at startup, with a notice. Use 0 to
+restore the default (%d days).`, config.DefaultSyncQueueMaxAgeDays),
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			switch days := settings.SyncQueueMaxAgeDays; {
+			case days < 0:
+				fmt.Println("Sync queue max age: forever")
+			case days == 0:
+				fmt.Printf("Sync queue max age: %d days\n", config.DefaultSyncQueueMaxAgeDays)
+			default:
+				fmt.Printf("Sync queue max age: %d days\n", days)
+			}
+			fmt.Printf("Queued changes: %d\n", api.GetSyncQueue().GetPendingCount())
+			return
+		}
+
+		days := -1
+		if args[0] != "forever" {
+			days, err = strconv.Atoi(args[0])
+			if err != nil || days < 0 {
+				fmt.Fprintf(os.Stderr, "Error: invalid number of days %q\n", args[0])
+				os.Exit(1)
+			}
+		}
+		settings.SyncQueueMaxAgeDays = days
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Sync queue max age updated")
+	},
+}
+
+var confirmationsCmd = &cobra.Command{
+	Use:   "confirmations [always|destructive|never]",
+	Short: "Show or set which actions ask for confirmation",
+	Long: `Choose when the app asks before acting:
+
+  always       every unsubscribe, deletion and removal
+  destructive  risky and irreversible actions only: unsubscribing from
+               transactional senders, deleting accounts, cloud data or
+               history, and removing team members (default)
+  never        power mode, act immediately`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: config.ConfirmationPolicies,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			fmt.Printf("Confirmations: %s\n", settings.ConfirmationPolicy())
+			return
+		}
+
+		if err := config.ValidateConfirmationPolicy(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		settings.Confirmations = args[0]
+		if args[0] == config.ConfirmDestructive {
+			settings.Confirmations = "" // The default
+		}
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Confirmations set to %s\n", args[0])
+	},
+}
+
+var snapshotDetailCmd = &cobra.Command{
+	Use:   "snapshot-detail [totals|domains|senders]",
+	Short: "Show or set how much a snapshot shared with the web dashboard reveals",
+	Long: `Premium users can share a summary of an analysis with the web dashboard
+([S] on the dashboard, confirmed on every upload). Snapshots never contain
+email contents, subjects or links; this setting controls the rest:
+
+  totals   newsletter and email counts per category only (default)
+  domains  counts, categories and scores per sender domain
+  senders  counts, categories and scores per sender address`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: config.SnapshotDetails,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			fmt.Printf("Snapshot detail: %s\n", settings.SnapshotDetailLevel())
+			return
+		}
+
+		if err := config.ValidateSnapshotDetail(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		settings.SnapshotDetail = args[0]
+		if args[0] == config.SnapshotTotals {
+			settings.SnapshotDetail = "" // The default
+		}
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Snapshot detail set to %s\n", args[0])
+	},
+}
+
+var unsubscribeNotesCmd = &cobra.Command{
+	Use:   "unsubscribe-notes [on|off]",
+	Short: "Keep a note in your mailbox for every unsubscribe",
+	Long: fmt.Sprintf(`When enabled, a short note documenting each unsubscribe (sender, date and
+method) is added to a folder of your mailbox, so the history is visible from
+any mail client. Notes go to the %q folder unless --folder is given; the
+folder is created on first use.`, config.DefaultNotesFolder),
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		notes := &settings.UnsubscribeNotes
+
+		folder, _ := cmd.Flags().GetString("folder")
+		if len(args) == 0 && folder == "" {
+			status := "off"
+			if notes.Enabled {
+				status = "on"
+			}
+			current := notes.Folder
+			if current == "" {
+				current = config.DefaultNotesFolder
+			}
+			fmt.Printf("Unsubscribe notes: %s (folder: %s)\n", status, current)
+			return
+		}
+
+		if len(args) == 1 {
+			switch args[0] {
+			case "on":
+				notes.Enabled = true
+			case "off":
+				notes.Enabled = false
+			default:
+				fmt.Fprintf(os.Stderr, "Error: expected on or off, got %q\n", args[0])
+				os.Exit(1)
+			}
+		}
+		if folder != "" {
+			notes.Folder = folder
+		}
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Unsubscribe notes updated")
+	},
+}
+
+var categorizerCmd = &cobra.Command{
+	Use:   "categorizer [remote|heuristic|local]",
+	Short: "Choose how newsletters are categorized",
+	Long: `Show or select the categorizer used on the dashboard:
+
+  remote     Enrichment API (default, needs an active subscription)
+  heuristic  Keyword rules on the sender address, works offline
+  local      Model trained on this machine, works offline
+
+Train the local model with --train. It learns from categories the
+enrichment API assigned before and from categories you set by hand.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: api.Categorizers,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		model, _ := cmd.Flags().GetString("model")
+		if model != "" {
+			settings.CategorizerModel = model
+		}
+
+		train, _ := cmd.Flags().GetBool("train")
+		if train {
+			trained, err := api.TrainLocalModel(api.LocalTrainingExamples())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := trained.Save(settings.CategorizerModel); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("✅ Trained local model on %d newsletter(s) in %d categories\n", trained.Examples, len(trained.Categories))
+		}
+
+		if len(args) == 0 && model == "" {
+			if !train {
+				name := settings.Categorizer
+				if name == "" {
+					name = api.CategorizerRemote
+				}
+				fmt.Printf("Categorizer: %s\n", name)
+			}
+			return
+		}
+
+		if len(args) == 1 {
+			valid := false
+			for _, name := range api.Categorizers {
+				valid = valid || args[0] == name
+			}
+			if !valid {
+				fmt.Fprintf(os.Stderr, "Error: unknown categorizer %q\n", args[0])
+				os.Exit(1)
+			}
+			settings.Categorizer = args[0]
+			if args[0] == api.CategorizerRemote {
+				settings.Categorizer = "" // The default
+			}
+		}
+
+		// Catch a missing model now rather than on the dashboard
+		if settings.Categorizer == api.CategorizerLocal {
+			if _, err := api.LoadLocalCategorizer(settings.CategorizerModel); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Categorizer updated")
+	},
+}
+
+var (
+	templateLangFlag     string
+	templateProviderFlag string
+	templateSubjectFlag  string
+	templateBodyFlag     string
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Customize the email sent for mailto unsubscribes",
+	Long: `Customize the subject and body of unsubscribe emails. Templates may use
+{{sender}}, {{account}} and {{date}}. A subject or body given in the
+newsletter's own mailto link always takes precedence.
+
+Examples:
+  newsletter-cli config template --lang de
+  newsletter-cli config template --lang fr --body "Merci de retirer {{account}}."
+  newsletter-cli config template --provider substack.com --subject "unsubscribe"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		templates := &settings.UnsubscribeTemplates
+
+		if !cmd.Flags().Changed("lang") && !cmd.Flags().Changed("provider") {
+			lang := templates.Language
+			if lang == "" {
+				lang = "en"
+			}
+			fmt.Printf("Language: %s\n", lang)
+			for code, t := range templates.Languages {
+				fmt.Printf("• [%s] %q / %q\n", code, t.Subject, t.Body)
+			}
+			for provider, t := range templates.Providers {
+				fmt.Printf("• %s: %q / %q\n", provider, t.Subject, t.Body)
+			}
+			return
+		}
+
+		t := config.MailTemplate{Subject: templateSubjectFlag, Body: templateBodyFlag}
+		hasTemplate := t.Subject != "" || t.Body != ""
+
+		if templateProviderFlag != "" {
+			if templates.Providers == nil {
+				templates.Providers = map[string]config.MailTemplate{}
+			}
+			if hasTemplate {
+				templates.Providers[strings.ToLower(templateProviderFlag)] = t
+			} else {
+				delete(templates.Providers, strings.ToLower(templateProviderFlag))
+			}
+		} else {
+			// --lang alone selects the language; with --subject/--body it defines it
+			templates.Language = strings.ToLower(templateLangFlag)
+			if hasTemplate {
+				if templates.Languages == nil {
+					templates.Languages = map[string]config.MailTemplate{}
+				}
+				templates.Languages[templates.Language] = t
+			}
+		}
+
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Unsubscribe template updated")
+	},
+}
+
+func init() {
+	categorizerCmd.Flags().Bool("train", false, "Train the local model from past and manual categories")
+	categorizerCmd.Flags().String("model", "", "Model file for the local categorizer")
+
+	unsubscribeNotesCmd.Flags().String("folder", "", "Mailbox folder for the notes")
+
+	templateCmd.Flags().StringVar(&templateLangFlag, "lang", "", "Language code (en, de, fr, es or your own)")
+	templateCmd.Flags().StringVar(&templateProviderFlag, "provider", "", "Unsubscribe address domain to override (omit --subject/--body to remove)")
+	templateCmd.Flags().StringVar(&templateSubjectFlag, "subject", "", "Email subject")
+	templateCmd.Flags().StringVar(&templateBodyFlag, "body", "", "Email body")
+
+	foldersCmd.Flags().Bool("reset", false, "Analyze INBOX only")
+	importUnsubscribedCmd.Flags().Bool("dry-run", false, "Show what would be imported without saving")
+	configCmd.AddCommand(exportProfileCmd, importProfileCmd, importUnsubscribedCmd, foldersCmd, categoryFeedbackCmd, templateCmd, maxMessageSizeCmd, bodyScanCmd, unsubscribeNotesCmd, categorizerCmd, noiseBudgetCmd, syncQueueMaxAgeCmd, confirmationsCmd, snapshotDetailCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+	"golang.org/x/term"
+)
+
+// confirmAction asks a yes/no question when the confirmation policy calls for
+// it. Without a terminal to ask on, the action is declined.
+func confirmAction(question string, destructive bool) bool {
+	if !config.ShouldConfirm(destructive) {
+		return true
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprintln(os.Stderr, "Error: confirmation required, pass --yes to proceed")
+		return false
+	}
+	fmt.Printf("%s [y/N] ", question)
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(input))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+	"github.com/loickal/newsletter-cli/internal/unsubscribe"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var doctorSMTPTestFlag string
+var doctorVerboseFlag bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that newsletter-cli is set up correctly",
+	Long: `Check the configuration, the selected account's IMAP login and the SMTP
+server used for mailto: unsubscribes, and preview the unsubscribe email.
+
+Unsubscribing through a mailto: link sends an email from your account. To
+make sure that works before it is needed, send a test with --smtp-test
+(to yourself) or --smtp-test=someone@example.com. When run in a terminal
+without the flag, doctor offers to send one.
+
+With --verbose, every capability the IMAP server advertises is listed along
+with what the optional ones mean for newsletter-cli on that account.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// The IMAP package logs progress that would clutter the report
+		if !doctorVerboseFlag {
+			log.SetOutput(io.Discard)
+		}
+
+		ok := true
+		check := func(name string, err error, detail string) bool {
+			if err != nil {
+				fmt.Printf("❌ %-20s %v\n", name, err)
+				ok = false
+				return false
+			}
+			fmt.Printf("✅ %-20s %s\n", name, detail)
+			return true
+		}
+
+		dir, err := config.ConfigDir()
+		if err == nil {
+			err = checkWritable(dir)
+		}
+		check("Config directory", err, dir)
+
+		account, err := config.GetSelectedAccount()
+		if err == nil && account == nil {
+			err = fmt.Errorf("no account selected, run `newsletter-cli login`")
+		}
+		if !check("Account", err, describeAccount(account)) {
+			os.Exit(1)
+		}
+
+		password, err := config.Decrypt(account.Password)
+		if err == nil && password == "" {
+			err = fmt.Errorf("no password saved")
+		}
+		if !check("Saved password", err, "decrypted") {
+			os.Exit(1)
+		}
+
+		// One session serves both the login and the capability checks
+		start := time.Now()
+		caps, err := imap.CheckCapabilities(account.Email, password, account.Server)
+		loginErr := err
+		if caps != nil {
+			loginErr = nil
+		}
+		if check("IMAP login", loginErr, fmt.Sprintf("%s (%dms)", account.Server, time.Since(start).Milliseconds())) {
+			if check("IMAP capabilities", err, summarizeCapabilities(caps)) && doctorVerboseFlag {
+				printCapabilities(caps)
+			}
+		}
+
+		smtpServer, err := unsubscribe.SMTPServerFor(account.Server)
+		smtpFound := check("SMTP server", err, smtpServer)
+
+		if pc, _ := api.GetPremiumConfig(); pc != nil && pc.Enabled {
+			err = nil
+			if api.IsSessionExpired() {
+				err = api.ErrSessionExpired
+			}
+			check("Premium session", err, pc.Email)
+		}
+
+		// Preview what a mailto: unsubscribe sends, with the user's templates
+		subject, body := unsubscribe.PreviewMailto("", "newsletter@example.com", account.Email)
+		fmt.Println("\n✉️  Unsubscribe email preview (lists may override subject and body):")
+		fmt.Printf("   Subject: %s\n", subject)
+		for _, line := range strings.Split(strings.TrimRight(body, "\r\n"), "\n") {
+			fmt.Printf("   %s\n", strings.TrimRight(line, "\r"))
+		}
+
+		to, send := doctorSMTPTestFlag, cmd.Flags().Changed("smtp-test")
+		if !send && smtpFound && term.IsTerminal(int(os.Stdin.Fd())) {
+			fmt.Printf("\nSend a test unsubscribe email? Enter an address, \"self\" for %s, or nothing to skip: ", account.Email)
+			input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			to = strings.TrimSpace(input)
+			send = to != ""
+		}
+		if send {
+			if to == "self" {
+				to = ""
+			}
+			fmt.Println()
+			result, err := unsubscribe.TestSMTP(account.Email, password, account.Server, to)
+			detail := ""
+			if result != nil {
+				detail = fmt.Sprintf("sent to %s via %s (%dms)", result.To, result.Server, result.Latency.Milliseconds())
+			}
+			check("SMTP send test", err, detail)
+		}
+
+		if !ok {
+			fmt.Println("\nSome checks failed.")
+			os.Exit(1)
+		}
+		fmt.Println("\nEverything looks good.")
+	},
+}
+
+// describeAccount summarizes the selected account for the report
+func describeAccount(account *config.Account) string {
+	if account == nil {
+		return ""
+	}
+	return account.Email
+}
+
+// summarizeCapabilities lists which optional capabilities the server has
+func summarizeCapabilities(caps *imap.Capabilities) string {
+	if caps == nil {
+		return ""
+	}
+	var parts []string
+	for _, feature := range imap.OptionalCapabilities {
+		mark := "✗"
+		if caps.Has(feature.Capability) {
+			mark = "✓"
+		}
+		parts = append(parts, feature.Capability+" "+mark)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// printCapabilities details the capabilities for --verbose
+func printCapabilities(caps *imap.Capabilities) {
+	fmt.Printf("   Advertised: %s\n", strings.Join(caps.All, " "))
+	for _, feature := range imap.OptionalCapabilities {
+		if caps.Has(feature.Capability) {
+			fmt.Printf("   ✓ %-12s %s\n", feature.Capability, feature.Supported)
+		} else {
+			fmt.Printf("   ✗ %-12s %s\n", feature.Capability, feature.Unsupported)
+		}
+	}
+	if caps.Has("QUOTA") {
+		if caps.QuotaLimit > 0 {
+			fmt.Printf("   Mailbox usage: %d of %d MB (%d%%)\n", caps.QuotaUsed/1024, caps.QuotaLimit/1024, uint64(caps.QuotaUsed)*100/uint64(caps.QuotaLimit))
+		} else {
+			fmt.Printf("   Mailbox usage: %d MB, no limit reported\n", caps.QuotaUsed/1024)
+		}
+	}
+	if len(caps.FolderRoles) > 0 {
+		var folders []string
+		for _, folder := range caps.FolderRoles {
+			folders = append(folders, strconv.Quote(folder))
+		}
+		sort.Strings(folders)
+		fmt.Printf("   Newsletters may also land in: %s\n", strings.Join(folders, ", "))
+		fmt.Printf("   Analyze them with: newsletter-cli config folders INBOX %s\n", strings.Join(folders, " "))
+	}
+}
+
+// checkWritable makes sure files can be created in dir
+func checkWritable(dir string) error {
+	name := filepath.Join(dir, ".doctor-check")
+	if err := config.WriteFile(name, nil, 0600); err != nil {
+		return err
+	}
+	return config.RemoveFile(name)
+}
+
+func init() {
+	doctorCmd.Flags().StringVar(&doctorSMTPTestFlag, "smtp-test", "", "Send a test unsubscribe email to this address (yourself when empty)")
+	doctorCmd.Flags().Lookup("smtp-test").NoOptDefVal = "self"
+	doctorCmd.Flags().BoolVarP(&doctorVerboseFlag, "verbose", "v", false, "Show IMAP logs and every server capability")
+	rootCmd.AddCommand(doctorCmd)
+}
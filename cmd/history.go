@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyPurgeAllFlag       bool
+	historyPurgeOlderThanFlag string
+	historyPurgeYesFlag       bool
+	historySessionsSinceFlag  string
+	historySessionsOnFlag     string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the local analysis history",
+
+	Annotations: map[string]string{localOnlyAnnotation: "true"},
+	Long: `Every analysis is summarized in a local history used for trends. It is
+encrypted with the same machine-bound key as your saved passwords and never
+leaves this machine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries, err := config.LoadHistory()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(entries) == 0 {
+			fmt.Println("No analysis history yet.")
+			return
+		}
+		for _, entry := range entries {
+			fmt.Printf("%s  %-32s %4d newsletters  %6d emails\n",
+				entry.Time.Format("2006-01-02 15:04"), entry.Account, entry.Newsletters, entry.Emails)
+		}
+	},
+}
+
+var historySessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Show what was done in past sessions of the app",
+	Long: `Every session of the interactive app keeps a transcript of the actions
+taken: analyses run, senders unsubscribed or kept, accounts added, switched or
+deleted. Transcripts are encrypted like the analysis history and follow its
+retention.
+
+Show the sessions of the last week with --since 7d, or of one day with
+--on 2024-05-14. Without either, the last 10 sessions are shown.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var since, until time.Time
+		switch {
+		case historySessionsSinceFlag != "" && historySessionsOnFlag != "":
+			fmt.Fprintln(os.Stderr, "Error: use either --since or --on")
+			os.Exit(1)
+		case historySessionsSinceFlag != "":
+			var err error
+			since, err = imap.ParseSearchWindow(historySessionsSinceFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid period %q\n", historySessionsSinceFlag)
+				os.Exit(1)
+			}
+		case historySessionsOnFlag != "":
+			day, err := time.ParseInLocation("2006-01-02", historySessionsOnFlag, time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid date %q, use YYYY-MM-DD\n", historySessionsOnFlag)
+				os.Exit(1)
+			}
+			since, until = day, day.AddDate(0, 0, 1)
+		}
+
+		transcripts, err := config.LoadTranscripts(since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !until.IsZero() {
+			for i, t := range transcripts {
+				if !t.Started.Before(until) {
+					transcripts = transcripts[:i]
+					break
+				}
+			}
+		}
+		if since.IsZero() && len(transcripts) > 10 {
+			transcripts = transcripts[len(transcripts)-10:]
+		}
+		if len(transcripts) == 0 {
+			fmt.Println("No sessions recorded for this period.")
+			return
+		}
+
+		for i, t := range transcripts {
+			if i > 0 {
+				fmt.Println()
+			}
+			end := t.Started
+			if len(t.Events) > 0 {
+				end = t.Events[len(t.Events)-1].Time
+			}
+			fmt.Printf("%s – %s\n", t.Started.Format("Mon 2006-01-02 15:04"), end.Format("15:04"))
+			for _, event := range t.Events {
+				fmt.Printf("  %s  %s\n", event.Time.Format("15:04"), event.Text)
+			}
+		}
+	},
+}
+
+var historyPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete analysis history and session transcripts",
+	Long: `Delete the whole analysis history and all session transcripts with --all,
+or only entries older than a period (90d, 2w, 3m, 1y) with --older-than.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var cutoff time.Time
+		switch {
+		case historyPurgeAllFlag && historyPurgeOlderThanFlag != "":
+			fmt.Fprintln(os.Stderr, "Error: use either --all or --older-than")
+			os.Exit(1)
+		case historyPurgeOlderThanFlag != "":
+			since, err := imap.ParseSearchWindow(historyPurgeOlderThanFlag)
+			if err != nil || since.IsZero() {
+				fmt.Fprintf(os.Stderr, "Error: invalid period %q\n", historyPurgeOlderThanFlag)
+				os.Exit(1)
+			}
+			cutoff = since
+		case !historyPurgeAllFlag:
+			fmt.Fprintln(os.Stderr, "Error: use --all to delete the whole history, or --older-than")
+			os.Exit(1)
+		}
+
+		question := "Delete the whole analysis history?"
+		if !cutoff.IsZero() {
+			question = fmt.Sprintf("Delete analysis history from before %s?", cutoff.Format("2006-01-02"))
+		}
+		if !historyPurgeYesFlag && !confirmAction(question, true) {
+			fmt.Println("Cancelled")
+			return
+		}
+
+		removed, err := config.PurgeHistory(cutoff)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sessions, err := config.PurgeTranscripts(cutoff)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Deleted %d history entries and %d session transcripts\n", removed, sessions)
+	},
+}
+
+var historyRetentionCmd = &cobra.Command{
+	Use:   "retention [months|forever]",
+	Short: "Show or set how long analysis history is kept",
+	Long: fmt.Sprintf(`Entries older than this are dropped whenever a new analysis is recorded
+and by the daily cleanup at startup.
+Use 0 to restore the default (%d months).`, config.DefaultHistoryRetentionMonths),
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := config.LoadSettings()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(args) == 0 {
+			switch months := settings.HistoryRetentionMonths; {
+			case months < 0:
+				fmt.Println("History retention: forever")
+			case months == 0:
+				fmt.Printf("History retention: %d months\n", config.DefaultHistoryRetentionMonths)
+			default:
+				fmt.Printf("History retention: %d months\n", months)
+			}
+			return
+		}
+
+		months := -1
+		if args[0] != "forever" {
+			months, err = strconv.Atoi(args[0])
+			if err != nil || months < 0 {
+				fmt.Fprintf(os.Stderr, "Error: invalid number of months %q\n", args[0])
+				os.Exit(1)
+			}
+		}
+		settings.HistoryRetentionMonths = months
+		if err := config.SaveSettings(settings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Apply the new retention right away
+		if cutoff := settings.HistoryCutoff(time.Now()); !cutoff.IsZero() {
+			if _, err := config.PurgeHistory(cutoff); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if _, err := config.PurgeTranscripts(cutoff); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		fmt.Println("✅ History retention updated")
+	},
+}
+
+func init() {
+	historyPurgeCmd.Flags().BoolVar(&historyPurgeAllFlag, "all", false, "Delete the whole history")
+	historyPurgeCmd.Flags().StringVar(&historyPurgeOlderThanFlag, "older-than", "", "Delete entries older than this period (e.g. 6m)")
+	historyPurgeCmd.Flags().BoolVarP(&historyPurgeYesFlag, "yes", "y", false, "Don't ask for confirmation")
+	historySessionsCmd.Flags().StringVar(&historySessionsSinceFlag, "since", "", "Show sessions from this period (e.g. 7d, 2w)")
+	historySessionsCmd.Flags().StringVar(&historySessionsOnFlag, "on", "", "Show sessions of one day (YYYY-MM-DD)")
+	historyCmd.AddCommand(historySessionsCmd, historyPurgeCmd, historyRetentionCmd)
+	rootCmd.AddCommand(historyCmd)
+}
@@ -29,7 +29,7 @@ var loginCmd = &cobra.Command{
 		}
 
 		currentVersion := getVersion()
-		if err := ui.RunAppSync(email, password, server, 0, false, "login", currentVersion); err != nil {
+		if err := ui.RunAppSync(email, password, server, "", false, "login", currentVersion); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
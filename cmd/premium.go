@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+var premiumCmd = &cobra.Command{
+	Use:   "premium",
+	Short: "Premium cloud sync tools",
+}
+
+var premiumSelftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check that cloud sync works end to end",
+	Long: `Write a probe record to the cloud, read it back and restore the original
+settings, reporting the latency of each request and any version or content
+mismatch. Useful when changes made on one device don't show up on another.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("🩺 Running sync self-test...")
+
+		report, err := api.RunSyncSelfTest()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, step := range report.Steps {
+			if step.Err != nil {
+				fmt.Printf("❌ %-26s %6dms  %v\n", step.Name, step.Latency.Milliseconds(), step.Err)
+				continue
+			}
+			fmt.Printf("✅ %-26s %6dms  version %d\n", step.Name, step.Latency.Milliseconds(), step.Version)
+		}
+		for _, mismatch := range report.Mismatches {
+			fmt.Printf("⚠️  %s\n", mismatch)
+		}
+
+		if !report.OK() {
+			fmt.Println("\nSync is not healthy.")
+			os.Exit(1)
+		}
+		fmt.Println("\nSync is healthy.")
+	},
+}
+
+var premiumUndoPullCmd = &cobra.Command{
+	Use:   "undo-pull",
+	Short: "Restore local data from before the last cloud pull",
+	Long: `Restore accounts, unsubscribed newsletters and settings to what they were
+right before the last pull from the cloud changed them. The cloud versions
+that pull brought in are skipped by automatic pulls from then on.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		snap, err := api.UndoLastPull()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Restored local data from before the pull of %s\n", snap.TakenAt.Format("2006-01-02 15:04"))
+	},
+}
+
+var premiumDevicesCmd = &cobra.Command{
+	Use:   "devices",
+	Short: "List the devices signed in to your premium account",
+	Run: func(cmd *cobra.Command, args []string) {
+		devices, err := api.ListDevices()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(devices) == 0 {
+			fmt.Println("No devices signed in")
+			return
+		}
+
+		for _, device := range devices {
+			lastSeen := "never"
+			if device.LastSeenAt != nil {
+				lastSeen = device.LastSeenAt.Local().Format("2006-01-02 15:04")
+			}
+			marker := ""
+			switch {
+			case device.Current:
+				marker = "  (this device)"
+			case device.WipePending:
+				marker = "  (revoked, wipe pending)"
+			case device.RevokedAt != nil:
+				marker = "  (revoked)"
+			}
+			fmt.Printf("%-34s %-24s %-8s last seen %s%s\n", device.ID, device.Name, device.Platform, lastSeen, marker)
+		}
+	},
+}
+
+var premiumDevicesRevokeWipeFlag bool
+var premiumDevicesRevokeYesFlag bool
+
+var premiumDevicesRevokeCmd = &cobra.Command{
+	Use:   "revoke <device-id>",
+	Short: "Sign out another device, e.g. a lost laptop",
+	Long: `Invalidate the premium tokens of another device so it can no longer sync.
+With --wipe the device also deletes its local synced data (accounts,
+unsubscribed newsletters, settings and premium login) the next time it starts.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if device, err := api.GetLocalDevice(); err == nil && device.ID == args[0] {
+			fmt.Fprintln(os.Stderr, "Error: that is this device, log out of premium instead")
+			os.Exit(1)
+		}
+
+		question := fmt.Sprintf("Sign out device %s?", args[0])
+		if premiumDevicesRevokeWipeFlag {
+			question = fmt.Sprintf("Sign out device %s and wipe its synced data?", args[0])
+		}
+		if !premiumDevicesRevokeYesFlag && !confirmAction(question, true) {
+			fmt.Println("Cancelled")
+			return
+		}
+
+		if err := api.RevokeDevice(args[0], premiumDevicesRevokeWipeFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if premiumDevicesRevokeWipeFlag {
+			fmt.Println("✅ Device signed out; it will wipe its synced data next time it starts")
+			return
+		}
+		fmt.Println("✅ Device signed out")
+	},
+}
+
+var premiumLicenseCmd = &cobra.Command{
+	Use:   "license",
+	Short: "Show the installed offline license",
+	Long: `Show the offline license, if one is installed. Offline licenses unlock
+premium features on machines that can't reach the license API, such as on
+isolated networks. They are checked locally, against their signature and
+expiry, and need no premium login.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		license, err := api.LoadOfflineLicense(time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if license == nil {
+			fmt.Println("No offline license installed")
+			return
+		}
+		printOfflineLicense(license)
+	},
+}
+
+var premiumLicenseInstallCmd = &cobra.Command{
+	Use:   "install <file>",
+	Short: "Install an offline license file",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		license, err := api.InstallOfflineLicense(args[0])
+		if errors.Is(err, api.ErrOfflineLicenseExpired) {
+			fmt.Fprintf(os.Stderr, "Error: %v, ask for a renewed license\n", err)
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Offline license installed")
+		printOfflineLicense(license)
+	},
+}
+
+func printOfflineLicense(license *api.OfflineLicense) {
+	fmt.Printf("License:  %s\n", license.ID)
+	fmt.Printf("Customer: %s\n", license.Customer)
+	fmt.Printf("Tier:     %s\n", license.Tier)
+	if license.MaxAccounts > 0 {
+		fmt.Printf("Accounts: up to %d\n", license.MaxAccounts)
+	}
+	fmt.Printf("Expires:  %s (%d days left)\n", license.ExpiresAt.Local().Format("2006-01-02"), int(time.Until(license.ExpiresAt).Hours()/24))
+}
+
+func init() {
+	premiumLicenseCmd.AddCommand(premiumLicenseInstallCmd)
+	premiumDevicesRevokeCmd.Flags().BoolVar(&premiumDevicesRevokeWipeFlag, "wipe", false, "Also wipe the device's local synced data on its next start")
+	premiumDevicesRevokeCmd.Flags().BoolVarP(&premiumDevicesRevokeYesFlag, "yes", "y", false, "Don't ask for confirmation")
+	premiumDevicesCmd.AddCommand(premiumDevicesRevokeCmd)
+
+	premiumCmd.AddCommand(premiumSelftestCmd, premiumUndoPullCmd, premiumDevicesCmd)
+	// Licenses can't be checked without the issuing key
+	if api.OfflineLicensesAccepted() {
+		premiumCmd.AddCommand(premiumLicenseCmd)
+	}
+	rootCmd.AddCommand(premiumCmd)
+}
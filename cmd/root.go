@@ -3,7 +3,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/loickal/newsletter-cli/internal/api"
 	"github.com/loickal/newsletter-cli/internal/config"
 	"github.com/loickal/newsletter-cli/internal/ui"
 	"github.com/spf13/cobra"
@@ -20,6 +23,40 @@ from newsletters using your IMAP inbox.
 Get started:
   newsletter-cli login     Save your IMAP credentials
   newsletter-cli analyze   Analyze and manage newsletters`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if readOnlyFlag {
+			config.SetReadOnly(true)
+			// A pending wipe can't run without changing local files, so it
+			// has to happen in a normal run first
+			if touchesSyncedData(cmd) && api.RemoteWipePending() {
+				fmt.Fprintln(os.Stderr, "Error: this device was signed out remotely and must wipe its synced data. Run once without --read-only to complete the wipe.")
+				os.Exit(1)
+			}
+			// Maintenance changes local files, it waits for a normal run
+			fmt.Fprintln(os.Stderr, "🔒 Read-only mode: analysis and browsing only, nothing will be changed.")
+			return
+		}
+
+		// A device revoked with a wipe deletes its synced data before doing
+		// anything with it
+		if touchesSyncedData(cmd) {
+			wiped, err := api.CheckRemoteWipe()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: remote wipe failed: %v\n", err)
+				os.Exit(1)
+			}
+			if wiped {
+				fmt.Println("🧹 This device was signed out remotely. Local synced data and the premium login were removed.")
+				return
+			}
+		}
+
+		// Maintenance is best effort and must never block the command
+		report, _ := api.RunMaintenance(time.Now())
+		if report != nil {
+			printMaintenanceNotice(report)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// Load selected account
 		account, _ := config.GetSelectedAccount()
@@ -40,7 +77,7 @@ Get started:
 		currentVersion := getVersion()
 
 		// Show unified UI - it will handle welcome screen and navigation
-		if err := ui.RunAppSync(email, password, server, 0, false, "", currentVersion); err != nil {
+		if err := ui.RunAppSync(email, password, server, "", false, "", currentVersion); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -49,6 +86,33 @@ Get started:
 
 var currentVersion string
 
+// readOnlyFlag disables every operation that changes something, for demos
+// and audits
+var readOnlyFlag bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&readOnlyFlag, "read-only", false, "Analyze and browse only: no unsubscribes, deletions, account changes or syncs")
+}
+
+// localOnlyAnnotation marks commands that never read synced data, so they
+// run without asking the backend about a remote wipe first
+const localOnlyAnnotation = "local-only"
+
+// touchesSyncedData reports whether cmd may read accounts, unsubscribes or
+// settings synced from the cloud
+func touchesSyncedData(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[localOnlyAnnotation] == "true" {
+			return false
+		}
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+	return true
+}
+
 func getVersion() string {
 	if currentVersion != "" {
 		return currentVersion
@@ -67,3 +131,30 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+// printMaintenanceNotice tells the user about queued changes that were given
+// up on; pruned caches and history need no notice
+func printMaintenanceNotice(report *api.MaintenanceReport) {
+	if len(report.DroppedSyncs) == 0 {
+		return
+	}
+
+	kinds := map[string]bool{}
+	var names []string
+	lastError := ""
+	for _, pending := range report.DroppedSyncs {
+		if !kinds[pending.Type] {
+			kinds[pending.Type] = true
+			names = append(names, pending.Type)
+		}
+		if pending.LastError != "" {
+			lastError = pending.LastError
+		}
+	}
+	fmt.Fprintf(os.Stderr, "🧹 Dropped %d queued sync change(s) (%s) that could not be synced in time.\n",
+		len(report.DroppedSyncs), strings.Join(names, ", "))
+	if lastError != "" {
+		fmt.Fprintf(os.Stderr, "   Last error: %s\n", lastError)
+	}
+	fmt.Fprintln(os.Stderr, "   Press Ctrl+S in the app to sync your current data. Change how long changes are retried with 'newsletter-cli config sync-queue-max-age'.")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/loickal/newsletter-cli/internal/update"
+	"github.com/spf13/cobra"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Release and update verification tools",
+
+	Annotations: map[string]string{localOnlyAnnotation: "true"},
+}
+
+var updateVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the installed binary against the signed release",
+	Long: `Download the release manifest for the installed version, check its minisign
+signature against the release keys built into this binary, then check that
+the installed executable is byte for byte the one published in that release.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		version := getVersion()
+		fmt.Printf("🔍 Verifying newsletter-cli %s...\n", version)
+
+		report, err := update.VerifyInstalled(version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✅ Manifest signed by key %s\n", report.KeyID)
+		if report.TrustedComment != "" {
+			fmt.Printf("   %s\n", report.TrustedComment)
+		}
+		fmt.Printf("✅ %s matches the manifest\n", report.Archive)
+		fmt.Printf("✅ %s matches release %s\n", report.Binary, report.Version)
+		fmt.Printf("   sha256 %s\n", report.SHA256)
+	},
+}
+
+var updateKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Show the release signing keys built into this binary",
+	Run: func(cmd *cobra.Command, args []string) {
+		keys, err := update.ReleaseKeys()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		for _, key := range keys {
+			fmt.Printf("🔑 %s\n", key.KeyID())
+		}
+	},
+}
+
+func init() {
+	// Nothing can be verified without a pinned key
+	if !update.HasReleaseKeys() {
+		return
+	}
+	updateCmd.AddCommand(updateVerifyCmd, updateKeysCmd)
+	rootCmd.AddCommand(updateCmd)
+}
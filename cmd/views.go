@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	viewTagsFlag       []string
+	viewCategoriesFlag []string
+	viewMinCountFlag   int
+	viewMaxCountFlag   int
+	viewAccountFlag    string
+)
+
+var viewsCmd = &cobra.Command{
+	Use:   "views",
+	Short: "Manage saved dashboard views",
+	Long: `Manage smart views: named filters shown as quick-switch entries
+above the dashboard. Press [Tab] in the dashboard to cycle through them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listViews()
+	},
+}
+
+var viewsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved views",
+	Run: func(cmd *cobra.Command, args []string) {
+		listViews()
+	},
+}
+
+var viewsAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Save a view (replaces an existing view with the same name)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		view := config.SmartView{
+			Name:       args[0],
+			Tags:       viewTagsFlag,
+			Categories: viewCategoriesFlag,
+			MinCount:   viewMinCountFlag,
+			MaxCount:   viewMaxCountFlag,
+			Account:    viewAccountFlag,
+		}
+		if err := config.SaveSmartView(view); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Saved view %q\n", view.Name)
+
+		// Sync to cloud if premium enabled
+		if api.IsPremiumEnabled() {
+			_ = api.SyncSettingsToCloud() // Queued for retry on failure
+		}
+	},
+}
+
+var viewsRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a saved view",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.DeleteSmartView(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("🗑️  Removed view %q\n", args[0])
+
+		if api.IsPremiumEnabled() {
+			_ = api.SyncSettingsToCloud()
+		}
+	},
+}
+
+func listViews() {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(settings.SmartViews) == 0 {
+		fmt.Println("No saved views. Create one with: newsletter-cli views add <name> --tag <tag>")
+		return
+	}
+	for _, v := range settings.SmartViews {
+		fmt.Printf("• %s  %s\n", v.Name, describeView(v))
+	}
+}
+
+func describeView(v config.SmartView) string {
+	var parts []string
+	if len(v.Tags) > 0 {
+		parts = append(parts, "tags: "+strings.Join(v.Tags, ", "))
+	}
+	if len(v.Categories) > 0 {
+		parts = append(parts, "categories: "+strings.Join(v.Categories, ", "))
+	}
+	if v.MinCount > 0 {
+		parts = append(parts, fmt.Sprintf("min: %d", v.MinCount))
+	}
+	if v.MaxCount > 0 {
+		parts = append(parts, fmt.Sprintf("max: %d", v.MaxCount))
+	}
+	if v.Account != "" {
+		parts = append(parts, "account: "+v.Account)
+	}
+	if len(parts) == 0 {
+		return "(no filters)"
+	}
+	return "(" + strings.Join(parts, " • ") + ")"
+}
+
+func init() {
+	viewsAddCmd.Flags().StringSliceVarP(&viewTagsFlag, "tag", "t", nil, "Tag the newsletter must have (repeatable)")
+	viewsAddCmd.Flags().StringSliceVarP(&viewCategoriesFlag, "category", "c", nil, "Category to include (repeatable)")
+	viewsAddCmd.Flags().IntVar(&viewMinCountFlag, "min-count", 0, "Minimum number of emails")
+	viewsAddCmd.Flags().IntVar(&viewMaxCountFlag, "max-count", 0, "Maximum number of emails (0 for no limit)")
+	viewsAddCmd.Flags().StringVarP(&viewAccountFlag, "account", "a", "", "Only apply to this account email")
+
+	viewsCmd.AddCommand(viewsListCmd, viewsAddCmd, viewsRemoveCmd)
+	rootCmd.AddCommand(viewsCmd)
+}
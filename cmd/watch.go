@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchIntervalFlag time.Duration
+	watchOnceFlag     bool
+	watchMetricsFlag  string
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch accounts for new newsletters in the background",
+	Long: `Periodically scan all accounts for newsletters from senders that have not
+been seen before. New senders are added to a pending review queue that is
+shown the next time you open the app, where each can be accepted
+(unsubscribe) or dismissed.
+
+The first scan of an account records existing newsletters as a baseline.
+Later scans follow your local clock with a little random delay, and scans
+missed while the computer was asleep are skipped rather than run at wake.
+
+With --metrics, counters for scans, newsletters found, unsubscribes and
+failed syncs are served in the Prometheus format at /metrics, e.g.
+--metrics :9464 or --metrics 127.0.0.1:9464.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		stop := make(chan struct{})
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sigs
+			close(stop)
+		}()
+
+		if watchMetricsFlag != "" {
+			server, err := watch.ServeMetrics(watchMetricsFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: metrics: %v\n", err)
+				os.Exit(1)
+			}
+			defer server.Close()
+			fmt.Printf("📈 Serving metrics on http://%s/metrics\n", watchMetricsFlag)
+		}
+
+		if !watchOnceFlag {
+			fmt.Printf("👀 Watching for new newsletters every %s (Ctrl+C to stop)\n", watchIntervalFlag)
+		}
+		if err := watch.Run(watchIntervalFlag, watchOnceFlag, stop); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	watchCmd.Flags().DurationVarP(&watchIntervalFlag, "interval", "i", time.Hour, "Time between scans")
+	watchCmd.Flags().BoolVar(&watchOnceFlag, "once", false, "Scan once and exit (for cron or systemd timers)")
+	watchCmd.Flags().StringVar(&watchMetricsFlag, "metrics", "", "Serve Prometheus metrics on this address (e.g. :9464)")
+	rootCmd.AddCommand(watchCmd)
+}
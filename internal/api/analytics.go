@@ -139,8 +139,8 @@ func (ac *AnalyticsCollector) flushEvents(events []AnalyticsEvent) error {
 		return nil
 	}
 
-	// Send batch to API
-	resp, err := ac.client.doRequestWithRefresh("POST", "/api/v1/analytics/events", map[string]interface{}{
+	// Send batch to API; the key lets the backend drop a batch resent after a timeout
+	resp, err := ac.client.WithIdempotencyKey(NewIdempotencyKey()).doRequestWithRefresh("POST", "/api/v1/analytics/events", map[string]interface{}{
 		"events": events,
 	})
 	if err != nil {
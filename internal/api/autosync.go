@@ -1,14 +1,17 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/loickal/newsletter-cli/internal/config"
 )
 
-// CheckAndSyncIfNeeded checks cloud versions and pulls if cloud is newer
+// CheckAndSyncIfNeeded checks cloud versions and pulls if cloud is newer.
+// Its requests are canceled along with ctx, so startup can bound them.
 // Returns true if sync was performed
-func CheckAndSyncIfNeeded() (bool, error) {
+func CheckAndSyncIfNeeded(ctx context.Context) (bool, error) {
 	if !IsPremiumEnabled() {
 		return false, nil // Silently skip if premium not enabled
 	}
@@ -17,6 +20,7 @@ func CheckAndSyncIfNeeded() (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	client = client.WithContext(ctx)
 
 	premiumConfig, err := GetPremiumConfig()
 	if err != nil {
@@ -24,14 +28,18 @@ func CheckAndSyncIfNeeded() (bool, error) {
 	}
 
 	synced := false
+	versionsChanged := false
+	snapshot := NewPullSnapshot()
 
-	// Check accounts version
+	// Check accounts version. The data fetched here is merged directly,
+	// rather than fetched again outside of ctx.
 	cloudAccountsData, err := client.GetAccounts()
 	if err == nil {
-		if cloudAccountsData.Version > premiumConfig.LocalAccountsVersion {
+		if cloudAccountsData.Version > premiumConfig.LocalAccountsVersion && cloudAccountsData.Version != premiumConfig.SkippedAccountsVersion {
+			snapshot.AccountsVersion = cloudAccountsData.Version
 			// Cloud has newer accounts, pull them
-			cloudAccounts, err := SyncAccountsFromCloud()
-			if err == nil {
+			var cloudAccounts []config.Account
+			if err := json.Unmarshal(cloudAccountsData.Accounts, &cloudAccounts); err == nil {
 				// Merge accounts
 				cfg, err := config.Load()
 				if err == nil {
@@ -57,6 +65,7 @@ func CheckAndSyncIfNeeded() (bool, error) {
 					} else {
 						// Even if no merge happened, update version to match cloud
 						premiumConfig.LocalAccountsVersion = cloudAccountsData.Version
+						versionsChanged = true
 					}
 				}
 			}
@@ -66,10 +75,11 @@ func CheckAndSyncIfNeeded() (bool, error) {
 	// Check unsubscribed version
 	cloudUnsubscribedData, err := client.GetUnsubscribed()
 	if err == nil {
-		if cloudUnsubscribedData.Version > premiumConfig.LocalUnsubscribedVersion {
+		if cloudUnsubscribedData.Version > premiumConfig.LocalUnsubscribedVersion && cloudUnsubscribedData.Version != premiumConfig.SkippedUnsubscribedVersion {
+			snapshot.UnsubscribedVersion = cloudUnsubscribedData.Version
 			// Cloud has newer unsubscribed data, pull it
-			cloudUnsubscribed, err := SyncUnsubscribedFromCloud()
-			if err == nil {
+			var cloudUnsubscribed config.UnsubscribedStore
+			if err := json.Unmarshal(cloudUnsubscribedData.Unsubscribed, &cloudUnsubscribed); err == nil {
 				localStore, _ := config.LoadUnsubscribed()
 				if localStore == nil {
 					localStore = &config.UnsubscribedStore{Newsletters: []config.UnsubscribedNewsletter{}}
@@ -77,12 +87,12 @@ func CheckAndSyncIfNeeded() (bool, error) {
 
 				localSenders := make(map[string]bool)
 				for _, n := range localStore.Newsletters {
-					localSenders[n.Sender] = true
+					localSenders[config.SenderKey(n.Sender)] = true
 				}
 
 				updated := false
 				for _, cloudNewsletter := range cloudUnsubscribed.Newsletters {
-					if !localSenders[cloudNewsletter.Sender] {
+					if !localSenders[config.SenderKey(cloudNewsletter.Sender)] {
 						localStore.Newsletters = append(localStore.Newsletters, cloudNewsletter)
 						updated = true
 					}
@@ -97,6 +107,23 @@ func CheckAndSyncIfNeeded() (bool, error) {
 				} else {
 					// Even if no merge happened, update version to match cloud
 					premiumConfig.LocalUnsubscribedVersion = cloudUnsubscribedData.Version
+					versionsChanged = true
+				}
+			}
+		}
+	}
+
+	// Check shared settings version
+	cloudConfigData, err := client.GetConfig()
+	if err == nil {
+		if cloudConfigData.Version > premiumConfig.LocalConfigVersion && cloudConfigData.Version != premiumConfig.SkippedConfigVersion {
+			snapshot.ConfigVersion = cloudConfigData.Version
+			updated, err := mergeCloudSettings(cloudConfigData)
+			if err == nil {
+				premiumConfig.LocalConfigVersion = cloudConfigData.Version
+				versionsChanged = true
+				if updated {
+					synced = true
 				}
 			}
 		}
@@ -104,6 +131,10 @@ func CheckAndSyncIfNeeded() (bool, error) {
 
 	// Save updated versions
 	if synced {
+		// Allow undoing what this pull merged
+		_ = snapshot.Save()
+	}
+	if synced || versionsChanged {
 		if err := SavePremiumConfig(premiumConfig); err != nil {
 			return false, fmt.Errorf("failed to save premium config: %w", err)
 		}
@@ -165,5 +196,12 @@ func PeriodicSync() error {
 		}
 	}
 
+	// Sync shared settings (smart views)
+	if err := SyncSettingsToCloud(); err != nil {
+		if syncErr == nil {
+			syncErr = err
+		}
+	}
+
 	return syncErr
 }
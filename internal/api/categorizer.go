@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// Categorizer assigns a category and quality score to newsletters. Results
+// are returned in the order of the input; newsletters it cannot place may
+// be left out.
+type Categorizer interface {
+	// Name identifies the implementation, as stored in the settings
+	Name() string
+	Categorize(newsletters []EnrichNewsletterInput) ([]EnrichNewsletter, error)
+}
+
+// Categorizer names accepted in the settings
+const (
+	CategorizerRemote    = "remote"    // Enrichment API, needs an active subscription
+	CategorizerHeuristic = "heuristic" // Keyword rules, works offline
+	CategorizerLocal     = "local"     // Local model trained on past categories
+)
+
+// Categorizers lists the available categorizer names
+var Categorizers = []string{CategorizerRemote, CategorizerHeuristic, CategorizerLocal}
+
+// NewCategorizer returns the categorizer selected in the settings, the
+// remote one by default. hasSubscription tells whether the remote API may be
+// used; without it the remote categorizer is unavailable and nil is returned,
+// so newsletters are shown without categories.
+func NewCategorizer(settings *config.Settings, hasSubscription bool) (Categorizer, error) {
+	name := CategorizerRemote
+	if settings != nil && settings.Categorizer != "" {
+		name = settings.Categorizer
+	}
+
+	switch name {
+	case CategorizerRemote:
+		if !hasSubscription {
+			return nil, nil
+		}
+		return remoteCategorizer{}, nil
+	case CategorizerHeuristic:
+		return heuristicCategorizer{}, nil
+	case CategorizerLocal:
+		path := ""
+		if settings != nil {
+			path = settings.CategorizerModel
+		}
+		return LoadLocalCategorizer(path)
+	}
+	return nil, fmt.Errorf("unknown categorizer %q", name)
+}
+
+// OfflineCategorizer stands in for the remote categorizer under an offline
+// license, whose machines can't reach the enrichment API: the local model
+// when one was trained, the keyword rules otherwise
+func OfflineCategorizer(settings *config.Settings) Categorizer {
+	path := ""
+	if settings != nil {
+		path = settings.CategorizerModel
+	}
+	if local, err := LoadLocalCategorizer(path); err == nil {
+		return local
+	}
+	return heuristicCategorizer{}
+}
+
+// remoteCategorizer uses the enrichment API, with caching
+type remoteCategorizer struct{}
+
+func (remoteCategorizer) Name() string { return CategorizerRemote }
+
+func (remoteCategorizer) Categorize(newsletters []EnrichNewsletterInput) ([]EnrichNewsletter, error) {
+	return EnrichNewslettersWithCache(newsletters)
+}
@@ -0,0 +1,82 @@
+package api
+
+import "strings"
+
+// heuristicRules map keywords found in a sender address to a category.
+// Rules are checked in order; the first match wins.
+var heuristicRules = []struct {
+	category string
+	keywords []string
+}{
+	{"Finance", []string{"bank", "invest", "capital", "finance", "paypal", "stripe", "crypto", "coinbase", "trading", "wallet", "insurance"}},
+	{"Technology", []string{"github", "gitlab", "dev", "tech", "cloud", "software", "digitalocean", "aws", "google", "microsoft", "apple", "jetbrains", "docker"}},
+	{"News/Media", []string{"news", "times", "post", "journal", "guardian", "reuters", "bloomberg", "medium", "substack", "daily", "weekly", "digest"}},
+	{"Promotional", []string{"deals", "offers", "offer", "sale", "shop", "store", "coupon", "promo", "discount", "rewards"}},
+	{"Marketing", []string{"marketing", "mailchimp", "hubspot", "sendgrid", "mailer", "campaign", "info", "hello"}},
+	{"Subscriptions", []string{"account", "billing", "subscription", "membership", "notifications", "notify", "updates"}},
+}
+
+// heuristicCategorizer places newsletters with keyword rules on the sender
+// address. It needs no network access.
+type heuristicCategorizer struct{}
+
+func (heuristicCategorizer) Name() string { return CategorizerHeuristic }
+
+func (heuristicCategorizer) Categorize(newsletters []EnrichNewsletterInput) ([]EnrichNewsletter, error) {
+	result := make([]EnrichNewsletter, 0, len(newsletters))
+	for _, n := range newsletters {
+		category, confidence := heuristicCategory(n.Sender)
+		result = append(result, EnrichNewsletter{
+			Sender:       n.Sender,
+			Category:     NewsletterCategory{Category: category, Confidence: confidence},
+			QualityScore: heuristicQuality(n),
+		})
+	}
+	return result, nil
+}
+
+// heuristicCategory returns the category of the first matching rule
+func heuristicCategory(sender string) (string, float64) {
+	tokens := senderTokens(sender)
+	for _, rule := range heuristicRules {
+		for _, keyword := range rule.keywords {
+			for _, token := range tokens {
+				if strings.Contains(token, keyword) {
+					return rule.category, 0.5
+				}
+			}
+		}
+	}
+	return "Other", 0.2
+}
+
+// heuristicQuality scores a newsletter 0-100: an unsubscribe link is good
+// etiquette, very high volume and heavy tracking are not
+func heuristicQuality(n EnrichNewsletterInput) int {
+	score := 50
+	if n.HasUnsubscribe {
+		score += 20
+	} else {
+		score -= 20
+	}
+	if n.TrackingHeavy {
+		score -= 15
+	}
+	switch {
+	case n.EmailCount > 60:
+		score -= 25
+	case n.EmailCount > 20:
+		score -= 10
+	case n.EmailCount <= 5:
+		score += 10
+	}
+	return score
+}
+
+// senderTokens splits an address into lowercase words, e.g.
+// "news@mail.example-shop.com" → news, mail, example, shop, com
+func senderTokens(sender string) []string {
+	return strings.FieldsFunc(strings.ToLower(sender), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+}
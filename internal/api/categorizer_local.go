@@ -0,0 +1,198 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+const localModelFile = "categorizer_model.json"
+
+// LocalModel is a naive Bayes classifier over the words of sender
+// addresses. It is small enough to train and run on the user's machine.
+type LocalModel struct {
+	TrainedAt time.Time `json:"trained_at"`
+	Examples  int       `json:"examples"`
+
+	// Per category: log prior, log likelihood of each token and of tokens
+	// not seen during training
+	Categories map[string]LocalModelCategory `json:"categories"`
+}
+
+// LocalModelCategory holds the log probabilities learned for one category
+type LocalModelCategory struct {
+	Prior   float64            `json:"prior"`
+	Tokens  map[string]float64 `json:"tokens"`
+	Unknown float64            `json:"unknown"`
+}
+
+// localCategorizer classifies with a LocalModel; quality scores come from
+// the heuristic
+type localCategorizer struct {
+	model *LocalModel
+}
+
+func (localCategorizer) Name() string { return CategorizerLocal }
+
+func (c localCategorizer) Categorize(newsletters []EnrichNewsletterInput) ([]EnrichNewsletter, error) {
+	result := make([]EnrichNewsletter, 0, len(newsletters))
+	for _, n := range newsletters {
+		category, confidence := c.model.Predict(n.Sender)
+		result = append(result, EnrichNewsletter{
+			Sender:       n.Sender,
+			Category:     NewsletterCategory{Category: category, Confidence: confidence},
+			QualityScore: heuristicQuality(n),
+		})
+	}
+	return result, nil
+}
+
+// LocalModelPath returns where the local model is stored by default
+func LocalModelPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, localModelFile), nil
+}
+
+// LoadLocalCategorizer loads the model at path, or the default model when
+// path is empty
+func LoadLocalCategorizer(path string) (Categorizer, error) {
+	if path == "" {
+		var err error
+		if path, err = LocalModelPath(); err != nil {
+			return nil, err
+		}
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no local categorizer model at %s, train one with: newsletter-cli config categorizer --train", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var model LocalModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("invalid categorizer model: %w", err)
+	}
+	if len(model.Categories) == 0 {
+		return nil, fmt.Errorf("categorizer model has no categories")
+	}
+	return localCategorizer{model: &model}, nil
+}
+
+// Predict returns the most likely category for sender and its probability
+func (m *LocalModel) Predict(sender string) (string, float64) {
+	tokens := senderTokens(sender)
+
+	// Iterate in a fixed order so ties resolve the same way every time
+	names := make([]string, 0, len(m.Categories))
+	for name := range m.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scores := make([]float64, len(names))
+	best := 0
+	for i, name := range names {
+		c := m.Categories[name]
+		score := c.Prior
+		for _, token := range tokens {
+			if p, ok := c.Tokens[token]; ok {
+				score += p
+			} else {
+				score += c.Unknown
+			}
+		}
+		scores[i] = score
+		if score > scores[best] {
+			best = i
+		}
+	}
+
+	// Softmax over the log scores for a confidence
+	total := 0.0
+	for _, s := range scores {
+		total += math.Exp(s - scores[best])
+	}
+	return names[best], 1 / total
+}
+
+// TrainLocalModel fits a model to labeled senders (sender → category) with
+// Laplace smoothing
+func TrainLocalModel(examples map[string]string) (*LocalModel, error) {
+	if len(examples) == 0 {
+		return nil, fmt.Errorf("no categorized newsletters to learn from")
+	}
+
+	docs := map[string]int{}
+	counts := map[string]map[string]int{}
+	totals := map[string]int{}
+	vocabulary := map[string]bool{}
+	for sender, category := range examples {
+		docs[category]++
+		if counts[category] == nil {
+			counts[category] = map[string]int{}
+		}
+		for _, token := range senderTokens(sender) {
+			counts[category][token]++
+			totals[category]++
+			vocabulary[token] = true
+		}
+	}
+
+	model := &LocalModel{
+		TrainedAt:  time.Now(),
+		Examples:   len(examples),
+		Categories: map[string]LocalModelCategory{},
+	}
+	v := float64(len(vocabulary))
+	for category, n := range docs {
+		denominator := float64(totals[category]) + v
+		c := LocalModelCategory{
+			Prior:   math.Log(float64(n) / float64(len(examples))),
+			Tokens:  map[string]float64{},
+			Unknown: math.Log(1 / denominator),
+		}
+		for token, count := range counts[category] {
+			c.Tokens[token] = math.Log(float64(count+1) / denominator)
+		}
+		model.Categories[category] = c
+	}
+	return model, nil
+}
+
+// Save writes the model to path, or to the default location when path is empty
+func (m *LocalModel) Save(path string) error {
+	if path == "" {
+		var err error
+		if path, err = LocalModelPath(); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFile(path, data, 0600)
+}
+
+// LocalTrainingExamples collects categories the enrichment API assigned in
+// the past and the ones the user set by hand, which take precedence
+func LocalTrainingExamples() map[string]string {
+	examples := GetEnrichmentCache().Categories()
+	if settings, err := config.LoadSettings(); err == nil {
+		for sender, category := range settings.CategoryOverrides {
+			examples[sender] = category
+		}
+	}
+	return examples
+}
@@ -2,15 +2,20 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"runtime"
 	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
 )
 
 type Client struct {
@@ -19,7 +24,15 @@ type Client struct {
 	Token          string
 	RefreshToken   string
 	APISecret      string // Optional HMAC signing secret
+	IdempotencyKey string // Sent as Idempotency-Key on mutating requests when set
+	DeviceID       string // Identifies this install in the account's device list
+	DeviceSecret   string // Proves DeviceID when checking for a remote wipe
 	OnTokenRefresh func(newToken, newRefreshToken string) error // Callback to save new tokens
+
+	// OnSessionExpired is called when the refresh token is rejected
+	OnSessionExpired func()
+
+	ctx context.Context // Bounds every request when set, see WithContext
 }
 
 type AuthResponse struct {
@@ -99,19 +112,45 @@ func NewClient(baseURL string) *Client {
 		baseURL = "https://api.newsletter-cli.apps.paas-01.pulseflow.cloud"
 	}
 
-	return &Client{
+	client := &Client{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
+	if device, err := GetLocalDevice(); err == nil {
+		client.DeviceID = device.ID
+		client.DeviceSecret = device.Secret
+	}
+	return client
 }
 
 func (c *Client) SetToken(token string) {
 	c.Token = token
 }
 
+// WithContext returns a copy of the client whose requests are canceled
+// along with ctx
+func (c *Client) WithContext(ctx context.Context) *Client {
+	copied := *c
+	copied.ctx = ctx
+	return &copied
+}
+
+// readOnlyPosts are the POST requests that only read, allowed in read-only mode
+var readOnlyPosts = map[string]bool{
+	"/api/v1/premium/enrich-batch": true, // Categorizes the analysis, stores nothing
+	"/api/v1/auth/refresh":         true, // Keeps the session alive, see GetAPIClient
+}
+
 func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
+	// Everything else but GET changes something on the server
+	if method != "GET" && !(method == "POST" && readOnlyPosts[path]) {
+		if err := config.CheckWritable(); err != nil {
+			return nil, err
+		}
+	}
+
 	var bodyBytes []byte
 	var reqBody io.Reader
 	
@@ -124,7 +163,11 @@ func (c *Client) doRequest(method, path string, body interface{}) (*http.Respons
 		reqBody = bytes.NewBuffer(bodyBytes)
 	}
 
-	req, err := http.NewRequest(method, c.BaseURL+path, reqBody)
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +175,15 @@ func (c *Client) doRequest(method, path string, body interface{}) (*http.Respons
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if c.IdempotencyKey != "" && method != "GET" {
+		req.Header.Set("Idempotency-Key", c.IdempotencyKey)
+	}
+	if c.DeviceID != "" {
+		req.Header.Set("X-Device-ID", c.DeviceID)
+		req.Header.Set("X-Device-Secret", c.DeviceSecret)
+		req.Header.Set("X-Device-Name", deviceName())
+		req.Header.Set("X-Device-Platform", runtime.GOOS)
+	}
 
 	// Use HMAC signing if API secret is set, otherwise use JWT
 	if c.APISecret != "" {
@@ -339,6 +391,13 @@ func (c *Client) ValidateLicense(licenseKey string) (*LicenseResponse, error) {
 // doRequestWithRefresh performs a request and automatically refreshes token on 401
 func (c *Client) doRequestWithRefresh(method, path string, body interface{}) (*http.Response, error) {
 	resp, err := c.doRequest(method, path, body)
+
+	// Requests with an idempotency key are safe to resend after a network
+	// error; the backend deduplicates them by key
+	for attempt := 1; err != nil && c.IdempotencyKey != "" && attempt <= maxIdempotentRetries && isRetryableNetworkError(err); attempt++ {
+		time.Sleep(time.Duration(attempt) * time.Second)
+		resp, err = c.doRequest(method, path, body)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -349,6 +408,14 @@ func (c *Client) doRequestWithRefresh(method, path string, body interface{}) (*h
 
 		// Refresh token
 		if err := c.refreshTokenIfNeeded(); err != nil {
+			// A rejected refresh token won't recover by retrying
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && (apiErr.Code == http.StatusUnauthorized || apiErr.Code == http.StatusForbidden) {
+				if c.OnSessionExpired != nil {
+					c.OnSessionExpired()
+				}
+				return nil, ErrSessionExpired
+			}
 			return nil, fmt.Errorf("token expired and refresh failed: %w", err)
 		}
 
@@ -638,6 +705,7 @@ type EnrichNewsletterInput struct {
 	Sender         string `json:"sender"`
 	EmailCount     int    `json:"email_count"`
 	HasUnsubscribe bool   `json:"has_unsubscribe"`
+	TrackingHeavy  bool   `json:"tracking_heavy,omitempty"` // Most messages carry open or click tracking
 }
 
 // EnrichNewslettersResponse represents the response from enrichment API
@@ -645,6 +713,35 @@ type EnrichNewslettersResponse struct {
 	Enriched []EnrichNewsletter `json:"enriched"`
 }
 
+// NewsletterCategories are the categories assigned by enrichment
+var NewsletterCategories = []string{"Technology", "Finance", "Marketing", "Subscriptions", "Promotional", "News/Media", "Other"}
+
+// CategoryFeedback reports a category the user assigned by hand
+type CategoryFeedback struct {
+	Sender            string `json:"sender"`
+	PredictedCategory string `json:"predicted_category"`
+	Category          string `json:"category"`
+}
+
+// SubmitCategoryFeedback sends a user's category correction to improve enrichment
+func (c *Client) SubmitCategoryFeedback(feedback CategoryFeedback) error {
+	resp, err := c.doRequestWithRefresh("POST", "/api/v1/premium/category-feedback", feedback)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	return nil
+}
+
 // EnrichNewsletters enriches newsletters with categorization and quality scores
 func (c *Client) EnrichNewsletters(newsletters []EnrichNewsletterInput) (*EnrichNewslettersResponse, error) {
 	reqBody := EnrichNewslettersRequest{Newsletters: newsletters}
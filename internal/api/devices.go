@@ -0,0 +1,314 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+const deviceFile = "device.json"
+
+// Device is an install that has signed in to the premium account
+type Device struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Platform    string     `json:"platform"`
+	LastSeenAt  *time.Time `json:"last_seen_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	WipePending bool       `json:"wipe_pending,omitempty"`
+	Current     bool       `json:"-"`
+}
+
+// DeviceStatus is what the backend tells an install about itself. It is
+// fetched with the device secret rather than a token, so a revoked device
+// still learns that it has to wipe.
+type DeviceStatus struct {
+	Revoked bool `json:"revoked"`
+	Wipe    bool `json:"wipe"`
+}
+
+// RevokeDeviceRequest invalidates a device's tokens, optionally asking it to
+// wipe its local synced data on next start
+type RevokeDeviceRequest struct {
+	Wipe bool `json:"wipe"`
+}
+
+// LocalDevice identifies this install to the backend. It is kept apart from
+// premium.json so it survives logging out and in again.
+type LocalDevice struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+func localDevicePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, deviceFile), nil
+}
+
+// GetLocalDevice returns this install's device identity, creating it on
+// first use
+func GetLocalDevice() (*LocalDevice, error) {
+	device, err := loadLocalDevice()
+	if err != nil || device != nil {
+		return device, err
+	}
+	path, err := localDevicePath()
+	if err != nil {
+		return nil, err
+	}
+
+	device = &LocalDevice{ID: NewIdempotencyKey(), Secret: NewIdempotencyKey()}
+	data, err := json.MarshalIndent(device, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := config.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// loadLocalDevice returns this install's device identity, nil when it has
+// none yet
+func loadLocalDevice() (*LocalDevice, error) {
+	path, err := localDevicePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var device LocalDevice
+	if err := json.Unmarshal(data, &device); err != nil || device.ID == "" {
+		return nil, nil // Replaced on first use
+	}
+	return &device, nil
+}
+
+// deviceName is how this install shows up in the device list
+func deviceName() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return runtime.GOOS
+	}
+	return name
+}
+
+// ListDevices returns the installs signed in to the account
+func (c *Client) ListDevices() ([]Device, error) {
+	resp, err := c.doRequestWithRefresh("GET", "/api/v1/devices", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	var devices []Device
+	if err := json.NewDecoder(resp.Body).Decode(&devices); err != nil {
+		return nil, err
+	}
+
+	for i := range devices {
+		devices[i].Current = devices[i].ID == c.DeviceID
+	}
+	return devices, nil
+}
+
+// RevokeDevice invalidates the tokens of another install. With wipe set the
+// install also deletes its local synced data the next time it starts.
+func (c *Client) RevokeDevice(deviceID string, wipe bool) error {
+	resp, err := c.doRequestWithRefresh("POST", "/api/v1/devices/"+url.PathEscape(deviceID)+"/revoke", RevokeDeviceRequest{
+		Wipe: wipe,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	return nil
+}
+
+// GetDeviceStatus asks whether this install was revoked from another device
+func (c *Client) GetDeviceStatus() (*DeviceStatus, error) {
+	resp, err := c.doRequest("GET", "/api/v1/devices/"+url.PathEscape(c.DeviceID)+"/status", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	var status DeviceStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// AcknowledgeWipe tells the backend the local data is gone, so the device
+// list stops showing the wipe as pending
+func (c *Client) AcknowledgeWipe() error {
+	resp, err := c.doRequest("POST", "/api/v1/devices/"+url.PathEscape(c.DeviceID)+"/wiped", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	return nil
+}
+
+// ListDevices returns the installs signed in to the premium account
+func ListDevices() ([]Device, error) {
+	client, err := GetAPIClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.ListDevices()
+}
+
+// RevokeDevice signs out another install, optionally wiping its synced data
+func RevokeDevice(deviceID string, wipe bool) error {
+	client, err := GetAPIClient()
+	if err != nil {
+		return err
+	}
+	return client.RevokeDevice(deviceID, wipe)
+}
+
+// CheckRemoteWipe runs on startup. If this install was revoked from another
+// device with a wipe requested, it deletes the local synced data and the
+// premium login and returns true. Network errors are ignored: the check is
+// repeated on the next start.
+func CheckRemoteWipe() (bool, error) {
+	client, wipe := remoteWipeRequested()
+	if !wipe {
+		return false, nil
+	}
+
+	if err := wipeSyncedData(); err != nil {
+		return false, err
+	}
+	_ = client.AcknowledgeWipe() // The backend keeps the flag until acknowledged; wiping again is harmless
+
+	return true, removeLocalPremium()
+}
+
+// RemoteWipePending reports whether this install was revoked with a wipe
+// requested, without wiping. Read-only runs use it to refuse to start.
+func RemoteWipePending() bool {
+	_, wipe := remoteWipeRequested()
+	return wipe
+}
+
+// remoteWipeRequested asks the backend whether this install has to wipe.
+// Network errors count as no. An install without a device identity was
+// never registered, so it can't have been revoked and nothing is asked.
+func remoteWipeRequested() (*Client, bool) {
+	cfg, err := GetPremiumConfig()
+	if err != nil || !cfg.Enabled {
+		return nil, false
+	}
+	device, err := loadLocalDevice()
+	if err != nil || device == nil {
+		return nil, false
+	}
+
+	client := NewClient(cfg.APIURL) // Picks up the device identity
+	client.HTTPClient = &http.Client{Timeout: 3 * time.Second}
+
+	status, err := client.GetDeviceStatus()
+	if err != nil || !status.Wipe {
+		return nil, false
+	}
+	return client, true
+}
+
+// wipeSyncedData deletes everything cloud sync brings to this install:
+// accounts, unsubscribed newsletters, settings, and the queue, snapshot and
+// cache derived from them
+func wipeSyncedData() error {
+	var paths []string
+	for _, pathFn := range []func() (string, error){config.ConfigPath, config.UnsubscribedPath, config.SettingsPath, pullSnapshotPath} {
+		path, err := pathFn()
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+	}
+
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return err
+	}
+	paths = append(paths, filepath.Join(dir, "sync_queue.json"))
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".newsletter-cli", ".cache", "enrichment_cache.json"))
+	}
+
+	for _, path := range paths {
+		if err := config.RemoveFile(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeLocalPremium deletes the premium login and the device identity, so
+// signing in again registers this install as a new device
+func removeLocalPremium() error {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{PremiumConfigFile, deviceFile} {
+		if err := config.RemoveFile(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
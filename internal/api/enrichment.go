@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+
+	"github.com/loickal/newsletter-cli/internal/config"
 )
 
 // EnrichNewslettersWithCache enriches newsletters using API with caching
@@ -102,3 +104,23 @@ func EnrichNewslettersSimple(newsletters []EnrichNewsletterInput) ([]EnrichNewsl
 
 	return response.Enriched, nil
 }
+
+// SubmitCategoryFeedback sends a manual category override as feedback, but
+// only if the user opted in to sharing corrections
+func SubmitCategoryFeedback(sender, predicted, category string) error {
+	settings, err := config.LoadSettings()
+	if err != nil || !settings.ShareCategoryFeedback {
+		return nil
+	}
+
+	client, err := GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	return client.WithIdempotencyKey(NewIdempotencyKey()).SubmitCategoryFeedback(CategoryFeedback{
+		Sender:            sender,
+		PredictedCategory: predicted,
+		Category:          category,
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
 )
 
 // CachedEnrichment represents cached enrichment data
@@ -30,15 +32,17 @@ var (
 	cacheOnce             sync.Once
 )
 
+// enrichmentCachePath returns the path to the enrichment cache file
+func enrichmentCachePath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".newsletter-cli", ".cache", "enrichment_cache.json")
+}
+
 // GetEnrichmentCache returns the global enrichment cache instance
 func GetEnrichmentCache() *EnrichmentCache {
 	cacheOnce.Do(func() {
-		var cacheDir string
-		homeDir, _ := os.UserHomeDir()
-		cacheDir = filepath.Join(homeDir, ".newsletter-cli", ".cache")
-
-		os.MkdirAll(cacheDir, 0755)
-		cacheFile := filepath.Join(cacheDir, "enrichment_cache.json")
+		cacheFile := enrichmentCachePath()
+		config.MkdirAll(filepath.Dir(cacheFile), 0755)
 
 		globalEnrichmentCache = &EnrichmentCache{
 			cache:     make(map[string]*CachedEnrichment),
@@ -136,7 +140,44 @@ func (ec *EnrichmentCache) Save() {
 		return
 	}
 
-	os.WriteFile(ec.cacheFile, data, 0644)
+	config.WriteFile(ec.cacheFile, data, 0644)
+}
+
+// PruneEnrichmentCache rewrites the cache file without its expired entries
+// and returns how many were removed
+func PruneEnrichmentCache() (int, error) {
+	path := enrichmentCachePath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var cached []CachedEnrichment
+	if err := json.Unmarshal(data, &cached); err != nil {
+		// Load ignores an invalid file, so nothing in it is worth keeping
+		return 0, config.RemoveFile(path)
+	}
+
+	now := time.Now()
+	kept := cached[:0]
+	for _, entry := range cached {
+		if now.Before(entry.ExpiresAt) {
+			kept = append(kept, entry)
+		}
+	}
+	removed := len(cached) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	data, err = json.Marshal(kept)
+	if err != nil {
+		return 0, err
+	}
+	return removed, config.WriteFile(path, data, 0644)
 }
 
 // Clear removes all cached entries
@@ -145,5 +186,20 @@ func (ec *EnrichmentCache) Clear() {
 	defer ec.mu.Unlock()
 
 	ec.cache = make(map[string]*CachedEnrichment)
-	os.Remove(ec.cacheFile)
+	config.RemoveFile(ec.cacheFile)
+}
+
+// Categories returns the category of every cached sender, including expired
+// entries; a category rarely changes even when the cache entry is stale
+func (ec *EnrichmentCache) Categories() map[string]string {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	categories := make(map[string]string, len(ec.cache))
+	for sender, entry := range ec.cache {
+		if entry.Category.Category != "" {
+			categories[sender] = entry.Category.Category
+		}
+	}
+	return categories
 }
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"time"
+)
+
+// maxIdempotentRetries is how many times a request carrying an idempotency
+// key is resent after a network error. Without a key, a request that timed
+// out may have succeeded server-side, so it is never resent automatically.
+const maxIdempotentRetries = 2
+
+// NewIdempotencyKey returns a random key identifying one logical write.
+// Retries of the same write must reuse the key so the backend can
+// deduplicate them.
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// Fall back to a time-based key; still unique per write in practice
+		return hex.EncodeToString([]byte(time.Now().UTC().Format(time.RFC3339Nano)))
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithIdempotencyKey returns a copy of the client that sends key as the
+// Idempotency-Key header on mutating requests
+func (c *Client) WithIdempotencyKey(key string) *Client {
+	copied := *c
+	copied.IdempotencyKey = key
+	return &copied
+}
+
+// isRetryableNetworkError reports whether err is a transport-level failure
+// (timeout, connection reset) where the server may or may not have applied
+// the request
+func isRetryableNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
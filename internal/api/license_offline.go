@@ -0,0 +1,170 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// offlineLicenseKeys are the Ed25519 public keys offline licenses are signed
+// with. Like the release keys they ship in the binary; add the new key here
+// before rotating and keep the old one until the licenses it signed expire.
+// The maintainer adds the public key of the license issuing service; until
+// then no offline license is accepted and the license commands are hidden.
+var offlineLicenseKeys = []string{}
+
+// OfflineLicensesAccepted reports whether this build can verify offline
+// licenses
+func OfflineLicensesAccepted() bool {
+	return len(offlineLicenseKeys) > 0
+}
+
+// ErrOfflineLicenseExpired is returned for an offline license past its expiry
+var ErrOfflineLicenseExpired = errors.New("offline license expired")
+
+// OfflineLicense unlocks premium features on machines that can't reach the
+// license API, such as on isolated enterprise networks. It is checked
+// locally, against its signature and expiry.
+type OfflineLicense struct {
+	ID          string    `json:"id"`
+	Customer    string    `json:"customer"`
+	Tier        string    `json:"tier"`
+	Features    []string  `json:"features"`
+	MaxAccounts int       `json:"max_accounts,omitempty"` // Overrides the tier's account limit when set
+	IssuedAt    time.Time `json:"issued_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// offlineLicenseFile is the license file as issued: the license JSON and
+// its signature, both base64 encoded, so the signed bytes survive any
+// reformatting of the file
+type offlineLicenseFile struct {
+	License   string `json:"license"`
+	Signature string `json:"signature"`
+}
+
+// OfflineLicensePath returns the path of the offline license file
+func OfflineLicensePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "license.json"), nil
+}
+
+// LoadOfflineLicense reads and validates the installed offline license. It
+// returns nil and no error when none is installed, or when this build
+// accepts no offline licenses.
+func LoadOfflineLicense(now time.Time) (*OfflineLicense, error) {
+	if !OfflineLicensesAccepted() {
+		return nil, nil
+	}
+	path, err := OfflineLicensePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseOfflineLicense(data, now)
+}
+
+// ParseOfflineLicense checks the signature and expiry of a license file
+func ParseOfflineLicense(data []byte, now time.Time) (*OfflineLicense, error) {
+	var file offlineLicenseFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid license file: %w", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(file.License)
+	if err != nil {
+		return nil, errors.New("invalid license file: license is not base64")
+	}
+	signature, err := base64.StdEncoding.DecodeString(file.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return nil, errors.New("invalid license file: malformed signature")
+	}
+
+	if len(offlineLicenseKeys) == 0 {
+		return nil, errors.New("this build accepts no offline licenses")
+	}
+	if !verifyOfflineLicense(payload, signature) {
+		return nil, errors.New("license signature is not valid")
+	}
+
+	var license OfflineLicense
+	if err := json.Unmarshal(payload, &license); err != nil {
+		return nil, fmt.Errorf("invalid license: %w", err)
+	}
+	if license.Tier == "" || license.ExpiresAt.IsZero() {
+		return nil, errors.New("invalid license: tier and expiry are required")
+	}
+	if !now.Before(license.ExpiresAt) {
+		return nil, fmt.Errorf("%w on %s", ErrOfflineLicenseExpired, license.ExpiresAt.Format("2006-01-02"))
+	}
+	return &license, nil
+}
+
+func verifyOfflineLicense(payload, signature []byte) bool {
+	for _, encoded := range offlineLicenseKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			continue
+		}
+		if ed25519.Verify(ed25519.PublicKey(key), payload, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// InstallOfflineLicense validates the license file at path and copies it to
+// the config directory
+func InstallOfflineLicense(path string) (*OfflineLicense, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	license, err := ParseOfflineLicense(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	dest, err := OfflineLicensePath()
+	if err != nil {
+		return nil, err
+	}
+	if err := config.MkdirAll(filepath.Dir(dest), 0700); err != nil {
+		return nil, err
+	}
+	return license, config.WriteFile(dest, data, 0600)
+}
+
+// FeatureMap returns the license in the shape of the license API's
+// features response
+func (l *OfflineLicense) FeatureMap() map[string]interface{} {
+	features := make([]interface{}, 0, len(l.Features))
+	for _, f := range l.Features {
+		features = append(features, f)
+	}
+	m := map[string]interface{}{
+		"tier":       l.Tier,
+		"features":   features,
+		"expires_at": l.ExpiresAt.Format(time.RFC3339),
+		"offline":    true,
+	}
+	if l.MaxAccounts > 0 {
+		m["max_accounts"] = float64(l.MaxAccounts) // As decoded from JSON
+	}
+	return m
+}
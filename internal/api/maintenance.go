@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// maintenanceInterval is how often startup maintenance runs
+const maintenanceInterval = 24 * time.Hour
+
+// MaintenanceReport is what startup maintenance cleaned up
+type MaintenanceReport struct {
+	DroppedSyncs   []PendingSync // Syncs queued for longer than the configured age
+	CacheEntries   int           // Expired enrichment cache entries
+	HistoryEntries int           // History entries past the retention
+	Transcripts    int           // Session transcripts past the retention
+}
+
+// maintenanceState remembers when maintenance last ran
+type maintenanceState struct {
+	LastRun time.Time `json:"last_run"`
+}
+
+func maintenancePath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "maintenance.json"), nil
+}
+
+// RunMaintenance prunes the sync queue, the enrichment cache and the
+// analysis history. It does nothing and returns nil when it already ran in
+// the last day.
+func RunMaintenance(now time.Time) (*MaintenanceReport, error) {
+	path, err := maintenancePath()
+	if err != nil {
+		return nil, err
+	}
+	var state maintenanceState
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	if now.Sub(state.LastRun) < maintenanceInterval {
+		return nil, nil
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		settings = &config.Settings{}
+	}
+
+	// Every step runs even when an earlier one failed; the first error is
+	// returned
+	report := &MaintenanceReport{}
+	var firstErr error
+	keep := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if cutoff := settings.SyncQueueCutoff(now); !cutoff.IsZero() {
+		report.DroppedSyncs, err = GetSyncQueue().DropOlderThan(cutoff)
+		keep(err)
+	}
+	report.CacheEntries, err = PruneEnrichmentCache()
+	keep(err)
+	// History is otherwise only pruned when an analysis is recorded, so it
+	// would outlive the retention on an account that is no longer analyzed
+	if cutoff := settings.HistoryCutoff(now); !cutoff.IsZero() {
+		report.HistoryEntries, err = config.PurgeHistory(cutoff)
+		keep(err)
+		report.Transcripts, err = config.PurgeTranscripts(cutoff)
+		keep(err)
+	}
+
+	data, err := json.Marshal(maintenanceState{LastRun: now})
+	if err == nil {
+		err = config.WriteFile(path, data, 0600)
+	}
+	keep(err)
+	return report, firstErr
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -18,6 +19,7 @@ type PremiumConfig struct {
 	APIURL                   string    `json:"api_url"`
 	Token                    string    `json:"token"`
 	RefreshToken             string    `json:"refresh_token"`
+	SessionExpired           bool      `json:"session_expired,omitempty"` // Refresh token was rejected, login required
 	Email                    string    `json:"email"`
 	Enabled                  bool      `json:"enabled"`
 	LastSyncTime             time.Time `json:"last_sync_time,omitempty"`
@@ -27,6 +29,12 @@ type PremiumConfig struct {
 	UnsubscribedCount        int       `json:"unsubscribed_count,omitempty"`
 	LocalAccountsVersion     int64     `json:"local_accounts_version,omitempty"`
 	LocalUnsubscribedVersion int64     `json:"local_unsubscribed_version,omitempty"`
+	LocalConfigVersion       int64     `json:"local_config_version,omitempty"`
+
+	// Cloud versions whose pull was undone; automatic pulls skip them
+	SkippedAccountsVersion     int64 `json:"skipped_accounts_version,omitempty"`
+	SkippedUnsubscribedVersion int64 `json:"skipped_unsubscribed_version,omitempty"`
+	SkippedConfigVersion       int64 `json:"skipped_config_version,omitempty"`
 
 	// Sync settings
 	AutoSyncOnStartup    bool `json:"auto_sync_on_startup,omitempty"`           // Default: true
@@ -122,17 +130,45 @@ func GetPremiumConfig() (*PremiumConfig, error) {
 }
 
 func SavePremiumConfig(cfg *PremiumConfig) error {
-	configDir, err := config.ConfigDir()
+	configPath, data, err := encodePremiumConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return config.WriteFile(configPath, data, 0600)
+}
+
+// savePremiumSession stores tokens rotated by a refresh. Unlike
+// SavePremiumConfig it also writes in read-only mode: the server invalidates
+// the old refresh token, so dropping the new one would sign the user out.
+func savePremiumSession(token, refreshToken string) error {
+	cfg, err := GetPremiumConfig()
 	if err != nil {
 		return err
 	}
+	cfg.Token = token
+	if refreshToken != "" {
+		cfg.RefreshToken = refreshToken
+	}
+	configPath, data, err := encodePremiumConfig(cfg)
+	if err != nil {
+		return err
+	}
+	return config.WriteSessionFile(configPath, data, 0600)
+}
+
+// encodePremiumConfig returns the path and contents of the premium config file
+func encodePremiumConfig(cfg *PremiumConfig) (string, []byte, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", nil, err
+	}
 
 	configPath := filepath.Join(configDir, PremiumConfigFile)
 
 	// Marshal to JSON first
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	// Handle analytics_enabled persistence:
@@ -156,7 +192,7 @@ func SavePremiumConfig(cfg *PremiumConfig) error {
 		data, _ = json.MarshalIndent(jsonMap, "", "  ")
 	}
 
-	return os.WriteFile(configPath, data, 0600)
+	return configPath, data, nil
 }
 
 func IsPremiumEnabled() bool {
@@ -172,6 +208,9 @@ func GetAPIClient() (*Client, error) {
 	if err != nil || !cfg.Enabled {
 		return nil, fmt.Errorf("premium features not enabled")
 	}
+	if cfg.SessionExpired {
+		return nil, ErrSessionExpired
+	}
 
 	client := NewClient(cfg.APIURL)
 	if cfg.Token != "" {
@@ -190,7 +229,10 @@ func GetAPIClient() (*Client, error) {
 		if newRefreshToken != "" {
 			cfg.RefreshToken = newRefreshToken
 		}
-		return SavePremiumConfig(cfg)
+		return savePremiumSession(newToken, newRefreshToken)
+	}
+	client.OnSessionExpired = func() {
+		_ = expireSession() // Requests fail with ErrSessionExpired either way
 	}
 
 	return client, nil
@@ -272,10 +314,15 @@ func SyncAccountsToCloud() error {
 	// Sync to cloud with shorter timeout for faster failure
 	// Create a client copy with 5s timeout for sync operations
 	syncClient := &Client{
-		BaseURL:      client.BaseURL,
-		HTTPClient:   &http.Client{Timeout: 5 * time.Second}, // Short timeout for UI responsiveness
-		Token:        client.Token,
-		RefreshToken: client.RefreshToken,
+		BaseURL:          client.BaseURL,
+		HTTPClient:       &http.Client{Timeout: 5 * time.Second}, // Short timeout for UI responsiveness
+		Token:            client.Token,
+		RefreshToken:     client.RefreshToken,
+		DeviceID:         client.DeviceID,
+		DeviceSecret:     client.DeviceSecret,
+		IdempotencyKey:   NewIdempotencyKey(),
+		OnTokenRefresh:   client.OnTokenRefresh,
+		OnSessionExpired: client.OnSessionExpired,
 	}
 
 	var accountsData *AccountsData
@@ -290,7 +337,7 @@ func SyncAccountsToCloud() error {
 		}
 		// Queue immediately for background retry instead of blocking
 		queue := GetSyncQueue()
-		queue.QueueSync("accounts", accounts)
+		queue.QueueSyncWithKey("accounts", accounts, syncClient.IdempotencyKey)
 		return fmt.Errorf("sync failed: %v (queued for background retry)", err)
 	}
 
@@ -327,6 +374,12 @@ func SyncAccountsFromCloud() ([]config.Account, error) {
 		return nil, err
 	}
 
+	// Update local version from cloud
+	if cfg, err := GetPremiumConfig(); err == nil {
+		cfg.LocalAccountsVersion = accountsData.Version
+		SavePremiumConfig(cfg) // Best effort, don't fail if this errors
+	}
+
 	// Parse accounts
 	var accounts []config.Account
 	if err := json.Unmarshal(accountsData.Accounts, &accounts); err != nil {
@@ -362,10 +415,15 @@ func SyncUnsubscribedToCloud() error {
 	// Sync to cloud with shorter timeout for faster failure
 	// Create a client copy with 5s timeout for sync operations
 	syncClient := &Client{
-		BaseURL:      client.BaseURL,
-		HTTPClient:   &http.Client{Timeout: 5 * time.Second}, // Short timeout for UI responsiveness
-		Token:        client.Token,
-		RefreshToken: client.RefreshToken,
+		BaseURL:          client.BaseURL,
+		HTTPClient:       &http.Client{Timeout: 5 * time.Second}, // Short timeout for UI responsiveness
+		Token:            client.Token,
+		RefreshToken:     client.RefreshToken,
+		DeviceID:         client.DeviceID,
+		DeviceSecret:     client.DeviceSecret,
+		IdempotencyKey:   NewIdempotencyKey(),
+		OnTokenRefresh:   client.OnTokenRefresh,
+		OnSessionExpired: client.OnSessionExpired,
 	}
 
 	var unsubscribedData *UnsubscribedData
@@ -379,7 +437,7 @@ func SyncUnsubscribedToCloud() error {
 		}
 		// Queue immediately for background retry instead of blocking
 		queue := GetSyncQueue()
-		queue.QueueSync("unsubscribed", store)
+		queue.QueueSyncWithKey("unsubscribed", store, syncClient.IdempotencyKey)
 		return fmt.Errorf("sync failed: %v (queued for background retry)", err)
 	}
 
@@ -445,7 +503,22 @@ func CheckLicense(licenseKey string) (*LicenseResponse, error) {
 
 // GetLicenseFeatures returns available features for current user
 func GetLicenseFeatures() (map[string]interface{}, error) {
+	return GetLicenseFeaturesContext(context.Background())
+}
+
+// GetLicenseFeaturesContext is GetLicenseFeatures, canceled along with ctx.
+// An installed offline license is used without asking the license API.
+func GetLicenseFeaturesContext(ctx context.Context) (map[string]interface{}, error) {
+	license, licenseErr := LoadOfflineLicense(time.Now())
+	if license != nil {
+		return license.FeatureMap(), nil
+	}
+
 	if !IsPremiumEnabled() {
+		if licenseErr != nil {
+			// Tell why the installed license doesn't unlock anything
+			return nil, fmt.Errorf("offline license: %w", licenseErr)
+		}
 		return nil, fmt.Errorf("premium features not enabled")
 	}
 
@@ -454,7 +527,7 @@ func GetLicenseFeatures() (map[string]interface{}, error) {
 		return nil, err
 	}
 
-	return client.GetLicenseFeatures()
+	return client.WithContext(ctx).GetLicenseFeatures()
 }
 
 // HasFeature checks if a specific premium feature is available
@@ -538,8 +611,11 @@ func CanAddAccount(currentAccountCount int) (bool, string) {
 		return true, ""
 	}
 
-	// Check account limit for tier
+	// Check account limit for tier, unless the license sets its own
 	maxAccounts := GetMaxAccountsForTier(tier)
+	if limit, ok := features["max_accounts"].(float64); ok && limit > 0 {
+		maxAccounts = int(limit)
+	}
 	if currentAccountCount >= maxAccounts {
 		return false, fmt.Sprintf("Account limit exceeded: Your %s plan allows up to %d accounts. Please upgrade your subscription.", tier, maxAccounts)
 	}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+const pullSnapshotFile = "pull_snapshot.json"
+
+// PullSnapshot is the local state from right before a cloud pull changed
+// it, along with the cloud versions that pull merged in
+type PullSnapshot struct {
+	TakenAt time.Time `json:"taken_at"`
+
+	// Raw contents of the local files, by path. A nil entry means the file
+	// did not exist.
+	Files map[string][]byte `json:"files"`
+
+	AccountsVersion     int64 `json:"accounts_version,omitempty"`
+	UnsubscribedVersion int64 `json:"unsubscribed_version,omitempty"`
+	ConfigVersion       int64 `json:"config_version,omitempty"`
+}
+
+// NewPullSnapshot captures the files a pull may change. It is only written
+// to disk by Save, once the pull actually changed something.
+func NewPullSnapshot() *PullSnapshot {
+	snap := &PullSnapshot{TakenAt: time.Now(), Files: map[string][]byte{}}
+	for _, pathFn := range []func() (string, error){config.ConfigPath, config.UnsubscribedPath, config.SettingsPath} {
+		path, err := pathFn()
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			snap.Files[path] = nil
+			continue
+		}
+		snap.Files[path] = data
+	}
+	return snap
+}
+
+func pullSnapshotPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, pullSnapshotFile), nil
+}
+
+// Save stores the snapshot as the one "undo last pull" restores
+func (s *PullSnapshot) Save() error {
+	path, err := pullSnapshotPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFile(path, data, 0600)
+}
+
+// LastPullSnapshot returns the snapshot of the last pull, or nil if there is
+// nothing to undo
+func LastPullSnapshot() (*PullSnapshot, error) {
+	path, err := pullSnapshotPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snap PullSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+// UndoLastPull restores the local state from before the last pull and marks
+// the cloud versions it merged as skipped, so automatic pulls don't bring
+// them back. Returns the restored snapshot.
+func UndoLastPull() (*PullSnapshot, error) {
+	if err := config.CheckWritable(); err != nil {
+		return nil, err
+	}
+	snap, err := LastPullSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	if snap == nil {
+		return nil, fmt.Errorf("no pull to undo")
+	}
+
+	for path, data := range snap.Files {
+		if data == nil {
+			if err := config.RemoveFile(path); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+		if err := config.WriteFile(path, data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	if cfg, err := GetPremiumConfig(); err == nil {
+		if snap.AccountsVersion > 0 {
+			cfg.SkippedAccountsVersion = snap.AccountsVersion
+		}
+		if snap.UnsubscribedVersion > 0 {
+			cfg.SkippedUnsubscribedVersion = snap.UnsubscribedVersion
+		}
+		if snap.ConfigVersion > 0 {
+			cfg.SkippedConfigVersion = snap.ConfigVersion
+		}
+		if err := SavePremiumConfig(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	path, err := pullSnapshotPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := config.RemoveFile(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return snap, nil
+}
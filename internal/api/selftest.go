@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// selfTestProbeKey is the config field the self-test writes its probe to
+const selfTestProbeKey = "selftest_probe"
+
+// SelfTestStep is the outcome of one request of the sync self-test
+type SelfTestStep struct {
+	Name    string
+	Latency time.Duration
+	Version int64
+	Err     error
+}
+
+// SelfTestReport summarizes a sync self-test. Mismatches lists everything
+// that did not behave like a healthy sync backend.
+type SelfTestReport struct {
+	Steps      []SelfTestStep
+	Mismatches []string
+}
+
+// OK reports whether every step succeeded without mismatches
+func (r *SelfTestReport) OK() bool {
+	for _, s := range r.Steps {
+		if s.Err != nil {
+			return false
+		}
+	}
+	return len(r.Mismatches) == 0
+}
+
+type selfTestProbe struct {
+	ID        string    `json:"id"`
+	WrittenAt time.Time `json:"written_at"`
+}
+
+// RunSyncSelfTest writes a probe to the cloud config, reads it back and
+// restores the original config, checking latencies and that versions
+// increase with every write. The returned error is only set when the test
+// could not start at all; failures of individual steps are in the report.
+func RunSyncSelfTest() (*SelfTestReport, error) {
+	if !IsPremiumEnabled() {
+		return nil, fmt.Errorf("premium features not enabled")
+	}
+
+	client, err := GetAPIClient()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SelfTestReport{}
+	step := func(name string, fn func() (*ConfigData, error)) *ConfigData {
+		start := time.Now()
+		data, err := fn()
+		s := SelfTestStep{Name: name, Latency: time.Since(start), Err: err}
+		if data != nil {
+			s.Version = data.Version
+		}
+		report.Steps = append(report.Steps, s)
+		return data
+	}
+
+	// 1. Read the current config so it can be restored afterwards
+	original := step("Read current config", client.GetConfig)
+	if original == nil {
+		return report, nil
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if len(original.Config) > 0 && string(original.Config) != "null" {
+		if err := json.Unmarshal(original.Config, &fields); err != nil {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("cloud config is not a JSON object: %v", err))
+			return report, nil
+		}
+	}
+
+	// 2. Write the probe next to the existing settings
+	probe := selfTestProbe{ID: NewIdempotencyKey(), WrittenAt: time.Now().UTC()}
+	probeJSON, _ := json.Marshal(probe)
+	fields[selfTestProbeKey] = probeJSON
+	probeConfig, _ := json.Marshal(fields)
+
+	written := step("Write probe", func() (*ConfigData, error) {
+		return client.WithIdempotencyKey(NewIdempotencyKey()).UpdateConfig(probeConfig)
+	})
+	if written == nil {
+		return report, nil
+	}
+	if written.Version <= original.Version {
+		report.Mismatches = append(report.Mismatches,
+			fmt.Sprintf("version did not increase on write (%d → %d)", original.Version, written.Version))
+	} else if written.Version > original.Version+1 {
+		report.Mismatches = append(report.Mismatches,
+			fmt.Sprintf("version jumped from %d to %d; another device may be writing at the same time", original.Version, written.Version))
+	}
+
+	// 3. Read the probe back
+	readBack := step("Read probe back", client.GetConfig)
+	if readBack != nil {
+		var readFields map[string]json.RawMessage
+		var readProbe selfTestProbe
+		if err := json.Unmarshal(readBack.Config, &readFields); err == nil {
+			_ = json.Unmarshal(readFields[selfTestProbeKey], &readProbe)
+		}
+		if readProbe.ID != probe.ID {
+			report.Mismatches = append(report.Mismatches, "probe read back does not match the probe written")
+		}
+		if readBack.Version != written.Version {
+			report.Mismatches = append(report.Mismatches,
+				fmt.Sprintf("read returned version %d, write returned %d", readBack.Version, written.Version))
+		}
+	}
+
+	// 4. Restore the original config, always attempted once the probe is written
+	delete(fields, selfTestProbeKey)
+	restoreConfig, _ := json.Marshal(fields)
+	restored := step("Restore original config", func() (*ConfigData, error) {
+		return client.WithIdempotencyKey(NewIdempotencyKey()).UpdateConfig(restoreConfig)
+	})
+	if restored == nil {
+		report.Mismatches = append(report.Mismatches,
+			"the probe is still stored in the cloud config; run the self-test again to remove it")
+		return report, nil
+	}
+	if restored.Version <= written.Version {
+		report.Mismatches = append(report.Mismatches,
+			fmt.Sprintf("version did not increase on restore (%d → %d)", written.Version, restored.Version))
+	}
+
+	// The restore is this device's own write, don't treat it as a remote change
+	if cfg, err := GetPremiumConfig(); err == nil && cfg.LocalConfigVersion == original.Version {
+		cfg.LocalConfigVersion = restored.Version
+		SavePremiumConfig(cfg) // Best effort
+	}
+
+	return report, nil
+}
@@ -0,0 +1,30 @@
+package api
+
+import "errors"
+
+// ErrSessionExpired is returned when the refresh token itself was rejected
+// and the user has to log in to premium again
+var ErrSessionExpired = errors.New("premium session expired, please log in again")
+
+// expireSession clears the rejected tokens so they are not sent again.
+// Without a token IsPremiumEnabled is false, which pauses syncing and the
+// sync queue; queued changes are kept until the user logs in again.
+func expireSession() error {
+	cfg, err := GetPremiumConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Token = ""
+	cfg.RefreshToken = ""
+	cfg.SessionExpired = true
+	return SavePremiumConfig(cfg)
+}
+
+// IsSessionExpired reports whether premium is set up but needs a new login
+func IsSessionExpired() bool {
+	cfg, err := GetPremiumConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.Enabled && cfg.SessionExpired
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// SyncedSettings is the part of the local settings shared across devices
+type SyncedSettings struct {
+	SmartViews []config.SmartView `json:"smart_views"`
+}
+
+// syncedSettingsFrom extracts the shared part of the local settings
+func syncedSettingsFrom(settings *config.Settings) SyncedSettings {
+	return SyncedSettings{SmartViews: settings.SmartViews}
+}
+
+// SyncSettingsToCloud pushes the shared settings (smart views) to the cloud
+func SyncSettingsToCloud() error {
+	if !IsPremiumEnabled() {
+		return fmt.Errorf("premium features not enabled")
+	}
+
+	client, err := GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return err
+	}
+	synced := syncedSettingsFrom(settings)
+
+	data, err := json.Marshal(synced)
+	if err != nil {
+		return err
+	}
+
+	// Short timeout for UI responsiveness, same as the other sync pushes
+	syncClient := &Client{
+		BaseURL:          client.BaseURL,
+		HTTPClient:       &http.Client{Timeout: 5 * time.Second},
+		Token:            client.Token,
+		RefreshToken:     client.RefreshToken,
+		DeviceID:         client.DeviceID,
+		DeviceSecret:     client.DeviceSecret,
+		IdempotencyKey:   NewIdempotencyKey(),
+		OnTokenRefresh:   client.OnTokenRefresh,
+		OnSessionExpired: client.OnSessionExpired,
+	}
+
+	configData, err := syncClient.UpdateConfig(data)
+	if err != nil {
+		if isSubscriptionError(err.Error()) {
+			return fmt.Errorf("sync failed: %v", err)
+		}
+		queue := GetSyncQueue()
+		queue.QueueSyncWithKey("settings", synced, syncClient.IdempotencyKey)
+		return fmt.Errorf("sync failed: %v (queued for background retry)", err)
+	}
+
+	cfg, err := GetPremiumConfig()
+	if err != nil {
+		return err
+	}
+	cfg.LastSyncTime = time.Now()
+	if configData != nil {
+		cfg.LocalConfigVersion = configData.Version
+	}
+	return SavePremiumConfig(cfg)
+}
+
+// SyncSettingsFromCloud pulls shared settings from the cloud and merges them
+// into the local settings. Smart views are merged by name; local views win.
+// Returns true if local settings changed.
+func SyncSettingsFromCloud() (bool, error) {
+	if !IsPremiumEnabled() {
+		return false, fmt.Errorf("premium features not enabled")
+	}
+
+	client, err := GetAPIClient()
+	if err != nil {
+		return false, err
+	}
+
+	configData, err := client.GetConfig()
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := mergeCloudSettings(configData)
+	if err != nil {
+		return false, err
+	}
+
+	// Update local version from cloud
+	if cfg, err := GetPremiumConfig(); err == nil {
+		cfg.LocalConfigVersion = configData.Version
+		SavePremiumConfig(cfg) // Best effort
+	}
+
+	return updated, nil
+}
+
+// mergeCloudSettings merges the shared settings of configData into the local
+// settings, returning true if they changed
+func mergeCloudSettings(configData *ConfigData) (bool, error) {
+	var cloud SyncedSettings
+	if len(configData.Config) > 0 && string(configData.Config) != "null" {
+		if err := json.Unmarshal(configData.Config, &cloud); err != nil {
+			return false, err
+		}
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		return false, err
+	}
+
+	localNames := make(map[string]bool)
+	for _, v := range settings.SmartViews {
+		localNames[strings.ToLower(v.Name)] = true
+	}
+
+	updated := false
+	for _, v := range cloud.SmartViews {
+		if !localNames[strings.ToLower(v.Name)] {
+			settings.SmartViews = append(settings.SmartViews, v)
+			updated = true
+		}
+	}
+
+	if updated {
+		if err := config.SaveSettings(settings); err != nil {
+			return false, err
+		}
+	}
+	return updated, nil
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// SnapshotSender is one row of an analysis snapshot. Depending on the detail
+// level it stands for a sender address or a whole sender domain.
+type SnapshotSender struct {
+	Sender       string `json:"sender,omitempty"` // Only at the "senders" detail level
+	Domain       string `json:"domain"`
+	Count        int    `json:"count"`
+	Category     string `json:"category,omitempty"`
+	QualityScore int    `json:"quality_score,omitempty"`
+	Unsubscribed bool   `json:"unsubscribed,omitempty"`
+}
+
+// AnalysisSnapshot summarizes an analysis for the web dashboard. It carries
+// counts and categories only, never message contents, subjects or links.
+type AnalysisSnapshot struct {
+	TakenAt      time.Time        `json:"taken_at"`
+	Since        time.Time        `json:"since,omitempty"`
+	Detail       string           `json:"detail"`
+	Newsletters  int              `json:"newsletters"`
+	Emails       int              `json:"emails"`
+	Unsubscribed int              `json:"unsubscribed"`
+	Categories   map[string]int   `json:"categories"` // Category -> emails
+	Senders      []SnapshotSender `json:"senders,omitempty"`
+}
+
+// NewAnalysisSnapshot builds a snapshot from per-sender rows, keeping only
+// what the detail level allows
+func NewAnalysisSnapshot(senders []SnapshotSender, since time.Time, detail string) *AnalysisSnapshot {
+	snap := &AnalysisSnapshot{
+		TakenAt:     time.Now(),
+		Since:       since,
+		Detail:      detail,
+		Newsletters: len(senders),
+		Categories:  map[string]int{},
+	}
+
+	domains := map[string]*SnapshotSender{}
+	largest := map[string]int{} // Domain -> count of its largest sender
+	for _, s := range senders {
+		snap.Emails += s.Count
+		if s.Unsubscribed {
+			snap.Unsubscribed++
+		}
+		category := s.Category
+		if category == "" {
+			category = "uncategorized"
+		}
+		snap.Categories[category] += s.Count
+
+		switch detail {
+		case config.SnapshotSenders:
+			s.Domain = senderDomain(s.Sender)
+			snap.Senders = append(snap.Senders, s)
+		case config.SnapshotDomains:
+			domain := senderDomain(s.Sender)
+			d, ok := domains[domain]
+			if !ok {
+				d = &SnapshotSender{Domain: domain}
+				domains[domain] = d
+			}
+			// The domain takes the category and score of its largest sender
+			if s.Count > largest[domain] {
+				largest[domain] = s.Count
+				d.Category = s.Category
+				d.QualityScore = s.QualityScore
+			}
+			d.Count += s.Count
+			d.Unsubscribed = d.Unsubscribed || s.Unsubscribed
+		}
+	}
+
+	for _, d := range domains {
+		snap.Senders = append(snap.Senders, *d)
+	}
+	sort.Slice(snap.Senders, func(i, j int) bool {
+		return snap.Senders[i].Count > snap.Senders[j].Count
+	})
+	return snap
+}
+
+func senderDomain(sender string) string {
+	if at := strings.LastIndex(sender, "@"); at != -1 {
+		return strings.ToLower(sender[at+1:])
+	}
+	return strings.ToLower(sender)
+}
+
+// UploadAnalysisSnapshot stores the snapshot as the latest overview shown on
+// the web dashboard
+func (c *Client) UploadAnalysisSnapshot(snap *AnalysisSnapshot) error {
+	resp, err := c.doRequestWithRefresh("POST", "/api/v1/analytics/snapshots", snap)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	return nil
+}
+
+// UploadAnalysisSnapshot shares the snapshot with the web dashboard
+func UploadAnalysisSnapshot(snap *AnalysisSnapshot) error {
+	client, err := GetAPIClient()
+	if err != nil {
+		return err
+	}
+	return client.WithIdempotencyKey(NewIdempotencyKey()).UploadAnalysisSnapshot(snap)
+}
@@ -167,5 +167,12 @@ func AutoSync() error {
 		}
 	}
 
+	// Sync shared settings (smart views)
+	if err := SyncSettingsToCloud(); err != nil {
+		if syncErr == nil {
+			syncErr = err
+		}
+	}
+
 	return syncErr
 }
@@ -240,13 +240,13 @@ func ThreeWayMergeUnsubscribed(localList []config.UnsubscribedNewsletter, cloudL
 	baseMap := make(map[string]bool)
 	
 	for _, n := range localList {
-		localMap[n.Sender] = n
+		localMap[config.SenderKey(n.Sender)] = n
 	}
 	for _, n := range cloudList {
-		cloudMap[n.Sender] = n
+		cloudMap[config.SenderKey(n.Sender)] = n
 	}
 	for _, n := range baseList {
-		baseMap[n.Sender] = true
+		baseMap[config.SenderKey(n.Sender)] = true
 	}
 	
 	// Collect all senders
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"sync"
@@ -12,11 +13,15 @@ import (
 
 // PendingSync represents a sync operation that failed and is queued for retry
 type PendingSync struct {
-	Type      string          `json:"type"`      // "accounts" or "unsubscribed"
+	Type      string          `json:"type"`      // "accounts", "unsubscribed" or "settings"
 	Data      json.RawMessage `json:"data"`      // The data to sync
 	QueuedAt  time.Time       `json:"queued_at"` // When it was queued
 	Retries   int             `json:"retries"`   // Number of retry attempts
 	LastError string          `json:"last_error,omitempty"`
+
+	// IdempotencyKey is reused on every retry so a write that timed out but
+	// actually succeeded is not applied twice
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // SyncQueue manages pending sync operations
@@ -42,6 +47,12 @@ func GetSyncQueue() *SyncQueue {
 
 // QueueSync adds a sync operation to the queue
 func (sq *SyncQueue) QueueSync(syncType string, data interface{}) error {
+	return sq.QueueSyncWithKey(syncType, data, NewIdempotencyKey())
+}
+
+// QueueSyncWithKey adds a sync operation to the queue, keeping the
+// idempotency key of the attempt that failed
+func (sq *SyncQueue) QueueSyncWithKey(syncType string, data interface{}, key string) error {
 	sq.mu.Lock()
 	defer sq.mu.Unlock()
 
@@ -51,13 +62,15 @@ func (sq *SyncQueue) QueueSync(syncType string, data interface{}) error {
 	}
 
 	pending := PendingSync{
-		Type:     syncType,
-		Data:     dataJSON,
-		QueuedAt: time.Now(),
-		Retries:  0,
+		Type:           syncType,
+		Data:           dataJSON,
+		QueuedAt:       time.Now(),
+		Retries:        0,
+		IdempotencyKey: key,
 	}
 
 	sq.pending = append(sq.pending, pending)
+	recordSyncFailures(1)
 	return sq.save()
 }
 
@@ -76,26 +89,54 @@ func (sq *SyncQueue) ProcessQueue() error {
 
 	var remaining []PendingSync
 	var lastErr error
+	paused := false
+	failures := 0
 
 	for _, pending := range sq.pending {
+		// Keep everything as is until the user logs in again
+		if paused {
+			remaining = append(remaining, pending)
+			continue
+		}
+
 		var err error
 
+		// Entries queued before keys were introduced get one now
+		if pending.IdempotencyKey == "" {
+			pending.IdempotencyKey = NewIdempotencyKey()
+		}
+
 		switch pending.Type {
 		case "accounts":
 			var accounts []config.Account
-			if err := json.Unmarshal(pending.Data, &accounts); err == nil {
+			if jsonErr := json.Unmarshal(pending.Data, &accounts); jsonErr == nil {
 				// Try to sync accounts
-				err = syncAccountsWithRetry(accounts, pending.Retries)
+				err = syncAccountsWithRetry(accounts, pending.Retries, pending.IdempotencyKey)
 			}
 		case "unsubscribed":
 			var unsubscribed *config.UnsubscribedStore
-			if err := json.Unmarshal(pending.Data, &unsubscribed); err == nil {
+			if jsonErr := json.Unmarshal(pending.Data, &unsubscribed); jsonErr == nil {
 				// Try to sync unsubscribed
-				err = syncUnsubscribedWithRetry(unsubscribed, pending.Retries)
+				err = syncUnsubscribedWithRetry(unsubscribed, pending.Retries, pending.IdempotencyKey)
 			}
+		case "settings":
+			var settings SyncedSettings
+			if jsonErr := json.Unmarshal(pending.Data, &settings); jsonErr == nil {
+				// Try to sync settings
+				err = syncSettingsWithRetry(settings, pending.Retries, pending.IdempotencyKey)
+			}
+		}
+
+		if errors.Is(err, ErrSessionExpired) {
+			remaining = append(remaining, pending)
+			lastErr = err
+			paused = true
+			continue
 		}
 
 		if err != nil {
+			failures++
+
 			// Check if error is subscription-related - don't retry those
 			errStr := err.Error()
 			if isSubscriptionError(errStr) {
@@ -121,12 +162,13 @@ func (sq *SyncQueue) ProcessQueue() error {
 
 	sq.pending = remaining
 	sq.save()
+	recordSyncFailures(failures)
 
 	return lastErr
 }
 
 // syncAccountsWithRetry syncs accounts with exponential backoff
-func syncAccountsWithRetry(accounts []config.Account, retries int) error {
+func syncAccountsWithRetry(accounts []config.Account, retries int, key string) error {
 	// Calculate delay: 1s, 2s, 4s
 	delay := time.Duration(1<<uint(retries)) * time.Second
 	if delay > 5*time.Second {
@@ -145,12 +187,12 @@ func syncAccountsWithRetry(accounts []config.Account, retries int) error {
 		return err
 	}
 
-	_, err = client.UpdateAccounts(accountsJSON)
+	_, err = client.WithIdempotencyKey(key).UpdateAccounts(accountsJSON)
 	return err
 }
 
 // syncUnsubscribedWithRetry syncs unsubscribed with exponential backoff
-func syncUnsubscribedWithRetry(unsubscribed *config.UnsubscribedStore, retries int) error {
+func syncUnsubscribedWithRetry(unsubscribed *config.UnsubscribedStore, retries int, key string) error {
 	// Calculate delay: 1s, 2s, 4s
 	delay := time.Duration(1<<uint(retries)) * time.Second
 	if delay > 5*time.Second {
@@ -169,7 +211,31 @@ func syncUnsubscribedWithRetry(unsubscribed *config.UnsubscribedStore, retries i
 		return err
 	}
 
-	_, err = client.UpdateUnsubscribed(unsubscribedJSON)
+	_, err = client.WithIdempotencyKey(key).UpdateUnsubscribed(unsubscribedJSON)
+	return err
+}
+
+// syncSettingsWithRetry syncs shared settings with exponential backoff
+func syncSettingsWithRetry(settings SyncedSettings, retries int, key string) error {
+	// Calculate delay: 1s, 2s, 4s
+	delay := time.Duration(1<<uint(retries)) * time.Second
+	if delay > 5*time.Second {
+		delay = 5 * time.Second // Cap at 5 seconds
+	}
+
+	time.Sleep(delay)
+
+	client, err := GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.WithIdempotencyKey(key).UpdateConfig(settingsJSON)
 	return err
 }
 
@@ -188,6 +254,50 @@ func (sq *SyncQueue) Clear() error {
 	return sq.save()
 }
 
+// DropOlderThan removes the syncs queued before cutoff and returns them
+func (sq *SyncQueue) DropOlderThan(cutoff time.Time) ([]PendingSync, error) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+
+	var kept, dropped []PendingSync
+	for _, pending := range sq.pending {
+		if pending.QueuedAt.Before(cutoff) {
+			dropped = append(dropped, pending)
+		} else {
+			kept = append(kept, pending)
+		}
+	}
+	if len(dropped) == 0 {
+		return nil, nil
+	}
+
+	sq.pending = kept
+	return dropped, sq.save()
+}
+
+// LoadPendingSyncs reads the queue from disk, for processes that only
+// report on it while the app owns it
+func LoadPendingSyncs() ([]PendingSync, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "sync_queue.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []PendingSync
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
 // save persists the queue to disk
 func (sq *SyncQueue) save() error {
 	configDir, err := config.ConfigDir()
@@ -201,7 +311,7 @@ func (sq *SyncQueue) save() error {
 		return err
 	}
 
-	return os.WriteFile(queuePath, data, 0600)
+	return config.WriteFile(queuePath, data, 0600)
 }
 
 // load loads the queue from disk
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// syncStats counts failed syncs across runs, for the watch daemon's metrics.
+// Unlike the queue, which shrinks as retries succeed, the count only grows.
+type syncStats struct {
+	Failures int `json:"failures"`
+}
+
+var syncStatsMu sync.Mutex
+
+func syncStatsPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sync_stats.json"), nil
+}
+
+func loadSyncStats() (syncStats, error) {
+	var stats syncStats
+	path, err := syncStatsPath()
+	if err != nil {
+		return stats, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	return stats, json.Unmarshal(data, &stats)
+}
+
+// recordSyncFailures adds n failed sync attempts to the count. It is best
+// effort: a count that can't be saved must not fail the sync any further.
+func recordSyncFailures(n int) {
+	if n == 0 {
+		return
+	}
+	syncStatsMu.Lock()
+	defer syncStatsMu.Unlock()
+
+	stats, err := loadSyncStats()
+	if err != nil {
+		return
+	}
+	stats.Failures += n
+	path, err := syncStatsPath()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return
+	}
+	_ = config.WriteFile(path, data, 0600)
+}
+
+// SyncFailures returns how many sync attempts have failed, including
+// retries of queued syncs
+func SyncFailures() (int, error) {
+	syncStatsMu.Lock()
+	defer syncStatsMu.Unlock()
+
+	stats, err := loadSyncStats()
+	return stats.Failures, err
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TeamSeat is a member, or pending invite, of an enterprise workspace
+type TeamSeat struct {
+	ID        string     `json:"id"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`   // "owner", "admin" or "member"
+	Status    string     `json:"status"` // "active" or "invited"
+	InvitedAt *time.Time `json:"invited_at,omitempty"`
+	JoinedAt  *time.Time `json:"joined_at,omitempty"`
+}
+
+// TeamSeats lists the seats of the workspace and how many the plan includes
+type TeamSeats struct {
+	Seats      []TeamSeat `json:"seats"`
+	TotalSeats int        `json:"total_seats"`
+	UsedSeats  int        `json:"used_seats"`
+}
+
+// TeamInviteRequest invites a teammate by email
+type TeamInviteRequest struct {
+	Email string `json:"email"`
+}
+
+// GetTeamSeats returns the seats of the user's workspace (enterprise only)
+func (c *Client) GetTeamSeats() (*TeamSeats, error) {
+	resp, err := c.doRequestWithRefresh("GET", "/api/v1/team/seats", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	var seats TeamSeats
+	if err := json.NewDecoder(resp.Body).Decode(&seats); err != nil {
+		return nil, err
+	}
+
+	return &seats, nil
+}
+
+// InviteTeamMember sends an invite to email and returns the pending seat
+func (c *Client) InviteTeamMember(email string) (*TeamSeat, error) {
+	resp, err := c.doRequestWithRefresh("POST", "/api/v1/team/invites", TeamInviteRequest{
+		Email: email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	var seat TeamSeat
+	if err := json.NewDecoder(resp.Body).Decode(&seat); err != nil {
+		return nil, err
+	}
+
+	return &seat, nil
+}
+
+// RemoveTeamMember frees a seat, removing the member or revoking the invite
+func (c *Client) RemoveTeamMember(seatID string) error {
+	resp, err := c.doRequestWithRefresh("DELETE", "/api/v1/team/seats/"+url.PathEscape(seatID), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(resp.Body)
+		return &APIError{
+			Message: string(body),
+			Code:    resp.StatusCode,
+		}
+	}
+
+	return nil
+}
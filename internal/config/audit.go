@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AuditEntry records one unsubscribe attempt. The log is append-only so it
+// can back up a complaint when a sender ignores an unsubscribe.
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Sender  string    `json:"sender"`
+	Account string    `json:"account,omitempty"` // Account email the unsubscribe was made for
+	Link    string    `json:"link,omitempty"`
+	Method  string    `json:"method,omitempty"` // Set when the unsubscribe succeeded
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// AuditPath returns the path to the unsubscribe audit log
+func AuditPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "audit.jsonl"), nil
+}
+
+// AppendAudit adds entries to the end of the audit log
+func AppendAudit(entries ...AuditEntry) error {
+	path, err := AuditPath()
+	if err != nil {
+		return err
+	}
+
+	f, err := OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadAudit returns the audit log entries for sender, oldest first, or all
+// entries when sender is empty
+func LoadAudit(sender string) ([]AuditEntry, error) {
+	path, err := AuditPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // Skip a line torn by a crash rather than lose the log
+		}
+		if sender == "" || entry.Sender == sender {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,56 @@
+package config
+
+import "fmt"
+
+// Confirmation policies
+const (
+	ConfirmAlways      = "always"      // Confirm every unsubscribe and destructive action
+	ConfirmDestructive = "destructive" // Confirm risky and irreversible actions only (default)
+	ConfirmNever       = "never"       // Power mode, act immediately
+)
+
+// ConfirmationPolicies lists the valid confirmation policies
+var ConfirmationPolicies = []string{ConfirmAlways, ConfirmDestructive, ConfirmNever}
+
+// ValidateConfirmationPolicy checks a policy name
+func ValidateConfirmationPolicy(policy string) error {
+	for _, p := range ConfirmationPolicies {
+		if policy == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown confirmation policy %q: use always, destructive or never", policy)
+}
+
+// ConfirmationPolicy returns the configured policy, ConfirmDestructive when
+// none is set
+func (s *Settings) ConfirmationPolicy() string {
+	if s.Confirmations == "" {
+		return ConfirmDestructive
+	}
+	return s.Confirmations
+}
+
+// ShouldConfirm reports whether an action needs confirmation. Destructive
+// actions are irreversible or risky ones such as deleting accounts or data
+// and unsubscribing from transactional senders.
+func (s *Settings) ShouldConfirm(destructive bool) bool {
+	switch s.ConfirmationPolicy() {
+	case ConfirmAlways:
+		return true
+	case ConfirmNever:
+		return false
+	default:
+		return destructive
+	}
+}
+
+// ShouldConfirm applies the saved confirmation policy, falling back to the
+// default when the settings can't be read
+func ShouldConfirm(destructive bool) bool {
+	settings, err := LoadSettings()
+	if err != nil {
+		settings = &Settings{}
+	}
+	return settings.ShouldConfirm(destructive)
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FrequencyStore holds the senders whose frequency the user reduced in their
+// preference center, and when
+type FrequencyStore struct {
+	Reduced map[string]time.Time `json:"reduced"` // Sender -> when it was reduced
+}
+
+// FrequencyPath returns the path to the reduced frequency file
+func FrequencyPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "frequency.json"), nil
+}
+
+// LoadFrequencyStore loads the senders whose frequency was reduced
+func LoadFrequencyStore() (*FrequencyStore, error) {
+	path, err := FrequencyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &FrequencyStore{Reduced: map[string]time.Time{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, err
+	}
+	if store.Reduced == nil {
+		store.Reduced = map[string]time.Time{}
+	}
+	return store, nil
+}
+
+// SaveFrequencyStore saves the senders whose frequency was reduced
+func SaveFrequencyStore(store *FrequencyStore) error {
+	path, err := FrequencyPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(path, data, 0600)
+}
+
+// SetFrequencyReduced records that the user reduced how often sender mails
+// them through its preference center, or clears the mark
+func SetFrequencyReduced(sender string, reduced bool) error {
+	store, err := LoadFrequencyStore()
+	if err != nil {
+		return err
+	}
+
+	sender = SenderKey(sender)
+	if reduced {
+		store.Reduced[sender] = time.Now()
+	} else {
+		delete(store.Reduced, sender)
+	}
+	return SaveFrequencyStore(store)
+}
+
+// IsFrequencyReduced reports whether the frequency of sender was reduced
+func (s *FrequencyStore) IsFrequencyReduced(sender string) bool {
+	_, ok := s.Reduced[SenderKey(sender)]
+	return ok
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// HistorySender is how much mail one sender sent in an analysis
+type HistorySender struct {
+	Sender string `json:"sender"`
+	Count  int    `json:"count"`
+}
+
+// HistoryEntry summarizes one analysis of one account, for trends over time
+type HistoryEntry struct {
+	Time        time.Time       `json:"time"`
+	Account     string          `json:"account"`
+	Since       time.Time       `json:"since"` // Start of the analyzed window, zero for all mail
+	Emails      int             `json:"emails"`
+	Newsletters int             `json:"newsletters"`
+	Senders     []HistorySender `json:"senders"`
+}
+
+// HistoryPath returns the path to the analysis history. The history shows
+// what the user subscribes to and how much they receive, so it is stored
+// encrypted with the same key as the account passwords.
+func HistoryPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.age"), nil
+}
+
+// LoadHistory returns the analysis history, oldest first
+func LoadHistory() ([]HistoryEntry, error) {
+	path, err := HistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := Decrypt(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var entries []HistoryEntry
+	if err := json.Unmarshal([]byte(plain), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// SaveHistory encrypts and stores the analysis history
+func SaveHistory(entries []HistoryEntry) error {
+	path, err := HistoryPath()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		if err := RemoveFile(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	encrypted, err := Encrypt(string(data))
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, []byte(encrypted), 0600)
+}
+
+// RecordAnalysis adds an entry to the history and drops entries older than
+// the retention setting
+func RecordAnalysis(entry HistoryEntry) error {
+	entries, err := LoadHistory()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+
+	cutoff := (&Settings{}).HistoryCutoff(entry.Time)
+	if settings, err := LoadSettings(); err == nil {
+		cutoff = settings.HistoryCutoff(entry.Time)
+	}
+	entries, _ = dropHistoryBefore(entries, cutoff)
+	return SaveHistory(entries)
+}
+
+// PurgeHistory removes entries recorded before cutoff, or all entries when
+// cutoff is the zero time, and returns how many were removed
+func PurgeHistory(cutoff time.Time) (int, error) {
+	if cutoff.IsZero() {
+		entries, err := LoadHistory()
+		if err != nil {
+			// An unreadable history can still be deleted
+			entries = nil
+		}
+		return len(entries), SaveHistory(nil)
+	}
+
+	entries, err := LoadHistory()
+	if err != nil {
+		return 0, err
+	}
+	kept, removed := dropHistoryBefore(entries, cutoff)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, SaveHistory(kept)
+}
+
+// dropHistoryBefore filters out entries recorded before cutoff
+func dropHistoryBefore(entries []HistoryEntry, cutoff time.Time) ([]HistoryEntry, int) {
+	if cutoff.IsZero() {
+		return entries, 0
+	}
+	kept := entries[:0]
+	for _, entry := range entries {
+		if !entry.Time.Before(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, len(entries) - len(kept)
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"strings"
+)
+
+// providerDomains maps a mail provider's domain to the domains its own
+// service notifications (security alerts, billing, account notices) come from
+var providerDomains = map[string][]string{
+	"gmail.com":      {"google.com", "accounts.google.com", "youtube.com"},
+	"googlemail.com": {"google.com", "accounts.google.com"},
+	"outlook.com":    {"microsoft.com", "accountprotection.microsoft.com", "outlook.com"},
+	"hotmail.com":    {"microsoft.com", "accountprotection.microsoft.com", "outlook.com"},
+	"live.com":       {"microsoft.com", "accountprotection.microsoft.com", "outlook.com"},
+	"office365.com":  {"microsoft.com", "accountprotection.microsoft.com"},
+	"icloud.com":     {"apple.com", "id.apple.com", "icloud.com"},
+	"me.com":         {"apple.com", "id.apple.com", "icloud.com"},
+	"mac.com":        {"apple.com", "id.apple.com", "icloud.com"},
+	"yahoo.com":      {"yahoo.com", "yahoo-inc.com"},
+	"aol.com":        {"aol.com", "yahoo-inc.com"},
+	"fastmail.com":   {"fastmail.com", "fastmail.fm"},
+	"proton.me":      {"proton.me", "protonmail.com"},
+	"protonmail.com": {"proton.me", "protonmail.com"},
+	"gmx.net":        {"gmx.net", "gmx.de"},
+	"gmx.de":         {"gmx.net", "gmx.de"},
+}
+
+// TrustedDomains returns the domains that must never be unsubscribed from:
+// the domains of the configured accounts and their IMAP servers, plus the
+// notification domains of the providers behind them
+func TrustedDomains(accounts []Account) []string {
+	seen := map[string]bool{}
+	var domains []string
+	add := func(d string) {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if d != "" && !seen[d] {
+			seen[d] = true
+			domains = append(domains, d)
+		}
+	}
+
+	for _, acc := range accounts {
+		var candidates []string
+		if at := strings.LastIndex(acc.Email, "@"); at != -1 {
+			candidates = append(candidates, acc.Email[at+1:])
+		}
+		if host := serverHost(acc.Server); host != "" {
+			candidates = append(candidates, BaseDomain(host))
+		}
+		for _, d := range candidates {
+			add(d)
+			for _, p := range providerDomains[strings.ToLower(d)] {
+				add(p)
+			}
+		}
+	}
+	return domains
+}
+
+// IsKept reports whether sender is protected, either because it is on the
+// user's keep list or because it comes from a trusted provider domain
+func IsKept(sender SenderIdentity, keepList, trustedDomains []string) bool {
+	if containsFold(keepList, sender.Key()) {
+		return true
+	}
+
+	domain := sender.Domain
+	if domain == "" {
+		return false
+	}
+
+	for _, list := range [][]string{keepList, trustedDomains} {
+		for _, d := range list {
+			d = strings.ToLower(strings.TrimPrefix(d, "@"))
+			if strings.Contains(d, "@") {
+				continue // Full address, handled above
+			}
+			if domain == d || strings.HasSuffix(domain, "."+d) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ToggleKeep adds entry (a sender address or domain) to the keep list, or
+// removes it if already present. Returns true if the entry is now kept.
+func ToggleKeep(entry string) (bool, error) {
+	settings, err := LoadSettings()
+	if err != nil {
+		return false, err
+	}
+
+	var keep []string
+	removed := false
+	for _, k := range settings.KeepList {
+		if strings.EqualFold(k, entry) {
+			removed = true
+			continue
+		}
+		keep = append(keep, k)
+	}
+	if !removed {
+		keep = append(keep, strings.ToLower(entry))
+	}
+	settings.KeepList = keep
+
+	return !removed, SaveSettings(settings)
+}
+
+// serverHost strips the port from an IMAP server address
+func serverHost(server string) string {
+	if i := strings.LastIndex(server, ":"); i != -1 {
+		server = server[:i]
+	}
+	return strings.ToLower(server)
+}
+
+// Common two-label public suffixes, enough for typical mail hosts
+var multiPartSuffixes = []string{"co.uk", "org.uk", "com.au", "co.nz", "co.jp", "com.br"}
+
+// BaseDomain reduces a host like imap.mail.example.com to example.com
+func BaseDomain(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) <= 2 {
+		return host
+	}
+	n := 2
+	if containsFold(multiPartSuffixes, strings.Join(parts[len(parts)-2:], ".")) {
+		n = 3 // e.g. example.co.uk
+	}
+	return strings.Join(parts[len(parts)-n:], ".")
+}
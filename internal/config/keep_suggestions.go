@@ -0,0 +1,153 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// KeepSuggestionInterval is how often the keep-list suggestions of an
+// account are refreshed from its latest analysis
+const KeepSuggestionInterval = 7 * 24 * time.Hour
+
+// KeepSuggestion is a sender the user engages with that is not on the keep
+// list yet
+type KeepSuggestion struct {
+	Sender      string    `json:"sender"`
+	Account     string    `json:"account"`
+	Count       int       `json:"count"`
+	Read        int       `json:"read"`
+	Replied     int       `json:"replied,omitempty"`
+	Starred     int       `json:"starred,omitempty"`
+	SuggestedAt time.Time `json:"suggested_at"`
+}
+
+// KeepSuggestionStore holds the current suggestions and the senders the user
+// dismissed, per account
+type KeepSuggestionStore struct {
+	Pending   []KeepSuggestion     `json:"pending"`
+	Dismissed map[string][]string  `json:"dismissed"` // Account email -> senders
+	LastRun   map[string]time.Time `json:"last_run"`  // Account email -> last refresh
+}
+
+// KeepSuggestionsPath returns the path to the keep suggestions file
+func KeepSuggestionsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "keep_suggestions.json"), nil
+}
+
+// LoadKeepSuggestions loads the keep suggestions
+func LoadKeepSuggestions() (*KeepSuggestionStore, error) {
+	path, err := KeepSuggestionsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &KeepSuggestionStore{
+		Dismissed: map[string][]string{},
+		LastRun:   map[string]time.Time{},
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, err
+	}
+	if store.Dismissed == nil {
+		store.Dismissed = map[string][]string{}
+	}
+	if store.LastRun == nil {
+		store.LastRun = map[string]time.Time{}
+	}
+	return store, nil
+}
+
+// SaveKeepSuggestions saves the keep suggestions
+func SaveKeepSuggestions(store *KeepSuggestionStore) error {
+	path, err := KeepSuggestionsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(path, data, 0600)
+}
+
+// Due reports whether the suggestions of account are due for a refresh
+func (s *KeepSuggestionStore) Due(account string, now time.Time) bool {
+	return now.Sub(s.LastRun[account]) >= KeepSuggestionInterval
+}
+
+// IsDismissed reports whether the user already turned down keeping sender
+func (s *KeepSuggestionStore) IsDismissed(account, sender string) bool {
+	return containsFold(s.Dismissed[account], sender)
+}
+
+// Replace sets the suggestions of account, dropping dismissed senders
+func (s *KeepSuggestionStore) Replace(account string, suggestions []KeepSuggestion, now time.Time) {
+	var pending []KeepSuggestion
+	for _, p := range s.Pending {
+		if p.Account != account {
+			pending = append(pending, p)
+		}
+	}
+	for _, suggestion := range suggestions {
+		if s.IsDismissed(account, suggestion.Sender) {
+			continue
+		}
+		suggestion.Account = account
+		suggestion.SuggestedAt = now
+		pending = append(pending, suggestion)
+	}
+	s.Pending = pending
+	s.LastRun[account] = now
+}
+
+// PendingFor returns the suggestions for account
+func (s *KeepSuggestionStore) PendingFor(account string) []KeepSuggestion {
+	var result []KeepSuggestion
+	for _, p := range s.Pending {
+		if p.Account == account {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// ResolveKeepSuggestion removes a suggestion once the user acted on it. A
+// dismissed sender is not suggested again.
+func ResolveKeepSuggestion(account, sender string, dismissed bool) error {
+	store, err := LoadKeepSuggestions()
+	if err != nil {
+		return err
+	}
+
+	var pending []KeepSuggestion
+	for _, p := range store.Pending {
+		if p.Account == account && strings.EqualFold(p.Sender, sender) {
+			continue
+		}
+		pending = append(pending, p)
+	}
+	store.Pending = pending
+	if dismissed && !store.IsDismissed(account, sender) {
+		store.Dismissed[account] = append(store.Dismissed[account], strings.ToLower(sender))
+	}
+
+	return SaveKeepSuggestions(store)
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// noiseWeeksKept is how many weeks of volume are kept
+const noiseWeeksKept = 8
+
+// NoiseWeek is the newsletter volume of one week, counted by the watch daemon
+type NoiseWeek struct {
+	Start     time.Time                 `json:"start"`      // Local Monday midnight
+	ByAccount map[string]map[string]int `json:"by_account"` // Account email -> sender -> emails
+	AlertedAt time.Time                 `json:"alerted_at,omitempty"`
+}
+
+// NoiseStore holds the weekly newsletter volume
+type NoiseStore struct {
+	Weeks []*NoiseWeek `json:"weeks"`
+}
+
+// SenderVolume is the number of emails one sender sent in a week
+type SenderVolume struct {
+	Sender string
+	Count  int
+}
+
+// WeekStart returns local Monday midnight of the week t falls in
+func WeekStart(t time.Time) time.Time {
+	t = t.Local()
+	offset := (int(t.Weekday()) + 6) % 7 // Days since Monday
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.Local)
+}
+
+// NoisePath returns the path to the weekly volume file
+func NoisePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "noise.json"), nil
+}
+
+// LoadNoise loads the weekly newsletter volume
+func LoadNoise() (*NoiseStore, error) {
+	path, err := NoisePath()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &NoiseStore{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// SaveNoise saves the weekly newsletter volume
+func SaveNoise(store *NoiseStore) error {
+	path, err := NoisePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, data, 0600)
+}
+
+// Week returns the entry for the week t falls in, nil if there is none
+func (s *NoiseStore) Week(t time.Time) *NoiseWeek {
+	start := WeekStart(t)
+	for _, w := range s.Weeks {
+		if w.Start.Equal(start) {
+			return w
+		}
+	}
+	return nil
+}
+
+// SetAccountVolume replaces the volume of account in the week t falls in.
+// Counts cover the whole week so far, which keeps repeated scans from
+// adding up the same messages.
+func (s *NoiseStore) SetAccountVolume(t time.Time, account string, counts map[string]int) *NoiseWeek {
+	week := s.Week(t)
+	if week == nil {
+		week = &NoiseWeek{Start: WeekStart(t), ByAccount: map[string]map[string]int{}}
+		s.Weeks = append(s.Weeks, week)
+		sort.Slice(s.Weeks, func(i, j int) bool { return s.Weeks[i].Start.Before(s.Weeks[j].Start) })
+		if len(s.Weeks) > noiseWeeksKept {
+			s.Weeks = s.Weeks[len(s.Weeks)-noiseWeeksKept:]
+		}
+	}
+	if week.ByAccount == nil {
+		week.ByAccount = map[string]map[string]int{}
+	}
+	week.ByAccount[account] = counts
+	return week
+}
+
+// Total returns the newsletter emails received in the week
+func (w *NoiseWeek) Total() int {
+	total := 0
+	for _, counts := range w.ByAccount {
+		for _, n := range counts {
+			total += n
+		}
+	}
+	return total
+}
+
+// TopSenders returns the n senders with the most emails in the week, across
+// accounts
+func (w *NoiseWeek) TopSenders(n int) []SenderVolume {
+	bySender := map[string]int{}
+	for _, counts := range w.ByAccount {
+		for sender, count := range counts {
+			bySender[sender] += count
+		}
+	}
+
+	volumes := make([]SenderVolume, 0, len(bySender))
+	for sender, count := range bySender {
+		volumes = append(volumes, SenderVolume{Sender: sender, Count: count})
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		if volumes[i].Count != volumes[j].Count {
+			return volumes[i].Count > volumes[j].Count
+		}
+		return volumes[i].Sender < volumes[j].Sender
+	})
+	if len(volumes) > n {
+		volumes = volumes[:n]
+	}
+	return volumes
+}
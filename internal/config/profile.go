@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ProfileVersion is the current profile file format version
+const ProfileVersion = 1
+
+// Profile is a shareable snapshot of the tool's settings. It never contains
+// accounts, passwords or premium tokens, so it is safe to hand to colleagues.
+type Profile struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Settings   Settings  `json:"settings"`
+}
+
+// ExportProfile writes the current settings to path as a profile file
+func ExportProfile(path string) error {
+	settings, err := LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	profile := Profile{
+		Version:    ProfileVersion,
+		ExportedAt: time.Now(),
+		Settings:   *settings,
+	}
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// ImportProfile replaces the current settings with the ones in the profile
+// at path. The previous settings are kept next to the settings file as a
+// .bak so an import can be undone by hand.
+func ImportProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("invalid profile file: %w", err)
+	}
+	if profile.Version == 0 || profile.Version > ProfileVersion {
+		return nil, fmt.Errorf("unsupported profile version %d", profile.Version)
+	}
+
+	settingsPath, err := SettingsPath()
+	if err != nil {
+		return nil, err
+	}
+	if current, err := os.ReadFile(settingsPath); err == nil {
+		if err := WriteFile(settingsPath+".bak", current, 0600); err != nil {
+			return nil, fmt.Errorf("failed to back up current settings: %w", err)
+		}
+	}
+
+	if err := SaveSettings(&profile.Settings); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrReadOnly is returned by every operation that would change something
+// while read-only mode is on
+var ErrReadOnly = errors.New("read-only mode, nothing can be changed")
+
+// readOnly is set once at startup by the --read-only flag
+var readOnly bool
+
+// SetReadOnly turns read-only mode on or off. In read-only mode analysis and
+// browsing work, but no file in the config directory, mailbox, cloud data or
+// subscription is changed. Only the premium login tokens are still saved when
+// a refresh rotates them, see WriteSessionFile.
+func SetReadOnly(on bool) {
+	readOnly = on
+}
+
+// ReadOnly reports whether read-only mode is on
+func ReadOnly() bool {
+	return readOnly
+}
+
+// CheckWritable returns ErrReadOnly in read-only mode
+func CheckWritable() error {
+	if readOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// WriteFile is os.WriteFile for the files newsletter-cli keeps its state in,
+// refused in read-only mode. Every change to the config directory and the
+// caches goes through it or one of the helpers below, so read-only mode is
+// enforced in one place.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if err := CheckWritable(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+// WriteSessionFile is WriteFile for the premium login tokens, the one write
+// allowed in read-only mode. Refreshing the session rotates the tokens on
+// the server, and keeping the session alive changes no user data.
+func WriteSessionFile(path string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(path, data, perm)
+}
+
+// OpenFile is os.OpenFile for writing to a state file, refused in read-only
+// mode
+func OpenFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	if err := CheckWritable(); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, flag, perm)
+}
+
+// RemoveFile is os.Remove for the files newsletter-cli keeps its state in,
+// refused in read-only mode
+func RemoveFile(path string) error {
+	if err := CheckWritable(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// MkdirAll is os.MkdirAll for the directories newsletter-cli keeps its state
+// in, refused in read-only mode
+func MkdirAll(path string, perm os.FileMode) error {
+	if err := CheckWritable(); err != nil {
+		return err
+	}
+	return os.MkdirAll(path, perm)
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PendingReview is a newsletter detected by the watch daemon that the user
+// has not yet accepted or dismissed
+type PendingReview struct {
+	Sender      string    `json:"sender"`
+	Account     string    `json:"account"` // Account email the newsletter was found in
+	Count       int       `json:"count"`
+	Unsubscribe string    `json:"unsubscribe,omitempty"`
+	DetectedAt  time.Time `json:"detected_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// ReviewStore holds the pending review queue and the senders already seen
+// per account, so the daemon only flags newsletters that are actually new
+type ReviewStore struct {
+	Pending      []PendingReview      `json:"pending"`
+	KnownSenders map[string][]string  `json:"known_senders"` // Account email -> senders
+	LastScan     map[string]time.Time `json:"last_scan"`     // Account email -> last scan time
+}
+
+// ReviewPath returns the path to the review queue file
+func ReviewPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "review.json"), nil
+}
+
+// LoadReviewStore loads the review queue
+func LoadReviewStore() (*ReviewStore, error) {
+	path, err := ReviewPath()
+	if err != nil {
+		return nil, err
+	}
+
+	store := &ReviewStore{
+		KnownSenders: map[string][]string{},
+		LastScan:     map[string]time.Time{},
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, err
+	}
+	if store.KnownSenders == nil {
+		store.KnownSenders = map[string][]string{}
+	}
+	if store.LastScan == nil {
+		store.LastScan = map[string]time.Time{}
+	}
+	return store, nil
+}
+
+// SaveReviewStore saves the review queue
+func SaveReviewStore(store *ReviewStore) error {
+	path, err := ReviewPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(path, data, 0600)
+}
+
+// IsKnownSender checks if sender has already been seen for account
+func (s *ReviewStore) IsKnownSender(account, sender string) bool {
+	for _, known := range s.KnownSenders[account] {
+		if strings.EqualFold(known, sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddKnownSender records sender as seen for account
+func (s *ReviewStore) AddKnownSender(account, sender string) {
+	if !s.IsKnownSender(account, sender) {
+		s.KnownSenders[account] = append(s.KnownSenders[account], sender)
+	}
+}
+
+// AddPending queues a newsletter for review, merging with an existing entry
+// for the same sender and account. IMAP searches have day granularity, so
+// consecutive scans overlap; the highest count seen is kept rather than summed.
+func (s *ReviewStore) AddPending(item PendingReview) {
+	for i, p := range s.Pending {
+		if strings.EqualFold(p.Sender, item.Sender) && p.Account == item.Account {
+			if item.Count > p.Count {
+				s.Pending[i].Count = item.Count
+			}
+			s.Pending[i].LastSeenAt = item.LastSeenAt
+			if s.Pending[i].Unsubscribe == "" {
+				s.Pending[i].Unsubscribe = item.Unsubscribe
+			}
+			return
+		}
+	}
+	s.Pending = append(s.Pending, item)
+}
+
+// PendingFor returns the pending reviews for account
+func (s *ReviewStore) PendingFor(account string) []PendingReview {
+	var result []PendingReview
+	for _, p := range s.Pending {
+		if p.Account == account {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// ResolvePending removes a reviewed newsletter from the queue. The sender
+// stays known, so it won't be flagged again.
+func ResolvePending(account, sender string) error {
+	store, err := LoadReviewStore()
+	if err != nil {
+		return err
+	}
+
+	var pending []PendingReview
+	for _, p := range store.Pending {
+		if p.Account == account && strings.EqualFold(p.Sender, sender) {
+			continue
+		}
+		pending = append(pending, p)
+	}
+	store.Pending = pending
+	store.AddKnownSender(account, sender)
+
+	return SaveReviewStore(store)
+}
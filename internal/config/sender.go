@@ -0,0 +1,71 @@
+package config
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// sharedSenderDomains send mail for many unrelated publishers, so the domain
+// says nothing about who a sender is
+var sharedSenderDomains = []string{
+	"substack.com", "beehiiv.com", "ghost.io", "buttondown.email", "mailchimp.com",
+	"convertkit.com", "medium.com", "gmail.com", "googlemail.com", "outlook.com",
+	"hotmail.com", "yahoo.com", "icloud.com",
+}
+
+// SenderIdentity is who a newsletter comes from. The address is the
+// identity: senders are compared, stored and synced by Key, while the other
+// fields are for display and grouping.
+type SenderIdentity struct {
+	Name    string `json:"name,omitempty"` // Display name from the From header, may be empty
+	Address string `json:"address"`        // Lowercased email address
+	Domain  string `json:"domain"`         // Domain of the address, e.g. news.example.com
+	// Brand groups the addresses of one organization, e.g. example.com for
+	// both news@example.com and deals@mail.example.com. Senders on shared
+	// domains such as substack.com are their own brand.
+	Brand string `json:"brand"`
+}
+
+// NewSenderIdentity builds the identity of a sender from the display name
+// and address of a From header
+func NewSenderIdentity(name, address string) SenderIdentity {
+	address = SenderKey(address)
+	id := SenderIdentity{Name: strings.TrimSpace(name), Address: address}
+	if at := strings.LastIndex(address, "@"); at != -1 {
+		id.Domain = strings.TrimSuffix(address[at+1:], ".")
+	}
+
+	id.Brand = BaseDomain(id.Domain)
+	if id.Domain == "" || containsFold(sharedSenderDomains, id.Brand) {
+		id.Brand = address
+	}
+	return id
+}
+
+// ParseSender builds the identity of a sender from a From header value,
+// either "Name <address>" or a bare address
+func ParseSender(from string) SenderIdentity {
+	if parsed, err := mail.ParseAddress(from); err == nil {
+		return NewSenderIdentity(parsed.Name, parsed.Address)
+	}
+	return NewSenderIdentity("", from)
+}
+
+// SenderKey normalizes a sender address, so the same sender is recognized
+// whatever the case of its From header
+func SenderKey(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// Key identifies the sender in maps, stores and sync payloads
+func (s SenderIdentity) Key() string {
+	return s.Address
+}
+
+// Label is the display name, or the address when the sender has none
+func (s SenderIdentity) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Address
+}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SmartView is a named dashboard filter combining tags, categories,
+// count thresholds and the account it applies to
+type SmartView struct {
+	Name       string   `json:"name"`
+	Tags       []string `json:"tags,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	MinCount   int      `json:"min_count,omitempty"`
+	MaxCount   int      `json:"max_count,omitempty"` // 0 means no upper bound
+	Account    string   `json:"account,omitempty"`   // Account email, empty for all accounts
+}
+
+// MailTemplate is a subject/body pair for unsubscribe emails. Both may use
+// the placeholders {{sender}}, {{account}} and {{date}}.
+type MailTemplate struct {
+	Subject string `json:"subject,omitempty"`
+	Body    string `json:"body,omitempty"`
+}
+
+// UnsubscribeTemplates configures the emails sent for mailto unsubscribes
+type UnsubscribeTemplates struct {
+	Language  string                  `json:"language,omitempty"`  // Language of the default template, e.g. "de"
+	Languages map[string]MailTemplate `json:"languages,omitempty"` // Custom templates by language code
+	Providers map[string]MailTemplate `json:"providers,omitempty"` // Overrides by unsubscribe address domain
+}
+
+// DefaultMaxMessageSizeMB is the size above which message bodies are not
+// downloaded during analysis
+const DefaultMaxMessageSizeMB = 5
+
+// DefaultHistoryRetentionMonths is how long analysis history is kept
+const DefaultHistoryRetentionMonths = 12
+
+// DefaultSyncQueueMaxAgeDays is how long changes that failed to sync are
+// retried before they are dropped
+const DefaultSyncQueueMaxAgeDays = 30
+
+// DefaultNotesFolder is the folder unsubscribe notes are stored in
+const DefaultNotesFolder = "Unsubscribed"
+
+// UnsubscribeNotes configures the notes stored in the mailbox for every
+// unsubscribe, so the history is visible from any mail client
+type UnsubscribeNotes struct {
+	Enabled bool   `json:"enabled,omitempty"`
+	Folder  string `json:"folder,omitempty"` // DefaultNotesFolder when empty
+}
+
+// Settings stores user preferences that are not tied to a single account
+type Settings struct {
+	SmartViews []SmartView `json:"smart_views,omitempty"`
+	Folders    []string    `json:"folders,omitempty"`   // Folders to analyze, INBOX when empty
+	KeepList   []string    `json:"keep_list,omitempty"` // Senders or domains never to unsubscribe from
+
+	// Categories assigned by hand, by sender, applied over enrichment results
+	CategoryOverrides map[string]string `json:"category_overrides,omitempty"`
+	// Send category overrides to the enrichment service as feedback (opt-in)
+	ShareCategoryFeedback bool `json:"share_category_feedback,omitempty"`
+	// Categorizer used on the dashboard: "remote" (default), "heuristic" or "local"
+	Categorizer string `json:"categorizer,omitempty"`
+	// Model file for the local categorizer, the default location when empty
+	CategorizerModel string `json:"categorizer_model,omitempty"`
+
+	UnsubscribeTemplates UnsubscribeTemplates `json:"unsubscribe_templates"`
+	UnsubscribeNotes     UnsubscribeNotes     `json:"unsubscribe_notes"`
+
+	// Messages larger than this many MB only have their headers fetched.
+	// 0 uses DefaultMaxMessageSizeMB, a negative value disables the limit.
+	MaxMessageSizeMB int `json:"max_message_size_mb,omitempty"`
+	// SkipBodyScan fetches only the headers of every message, giving up
+	// preference link and tracking detection in message bodies
+	SkipBodyScan bool `json:"skip_body_scan,omitempty"`
+
+	// Months of analysis history to keep. 0 uses
+	// DefaultHistoryRetentionMonths, a negative value keeps it forever.
+	HistoryRetentionMonths int `json:"history_retention_months,omitempty"`
+
+	// Days a change that failed to sync stays queued for retry. 0 uses
+	// DefaultSyncQueueMaxAgeDays, a negative value keeps it forever.
+	SyncQueueMaxAgeDays int `json:"sync_queue_max_age_days,omitempty"`
+
+	// Newsletter emails per week the watch daemon tolerates before it
+	// alerts, 0 for no budget
+	NoiseBudget int `json:"noise_budget,omitempty"`
+
+	// Which actions ask for confirmation: "always", "destructive" (default)
+	// or "never"
+	Confirmations string `json:"confirmations,omitempty"`
+
+	// How much of an analysis snapshot shared with the web dashboard
+	// reveals: "totals" (default), "domains" or "senders"
+	SnapshotDetail string `json:"snapshot_detail,omitempty"`
+
+	// AddressFirst shows sender addresses instead of display names first
+	AddressFirst bool `json:"address_first,omitempty"`
+
+	// HintsSeen is set once the first-run dashboard tips were shown
+	HintsSeen bool `json:"hints_seen,omitempty"`
+}
+
+// HistoryCutoff returns the time before which history entries are dropped,
+// the zero time when history is kept forever
+func (s *Settings) HistoryCutoff(now time.Time) time.Time {
+	months := s.HistoryRetentionMonths
+	switch {
+	case months < 0:
+		return time.Time{}
+	case months == 0:
+		months = DefaultHistoryRetentionMonths
+	}
+	return now.AddDate(0, -months, 0)
+}
+
+// SyncQueueCutoff returns the time before which queued syncs are dropped,
+// the zero time when they are kept forever
+func (s *Settings) SyncQueueCutoff(now time.Time) time.Time {
+	days := s.SyncQueueMaxAgeDays
+	switch {
+	case days < 0:
+		return time.Time{}
+	case days == 0:
+		days = DefaultSyncQueueMaxAgeDays
+	}
+	return now.AddDate(0, 0, -days)
+}
+
+// MaxMessageSize returns the body download limit in bytes, 0 for no limit
+func (s *Settings) MaxMessageSize() uint32 {
+	switch {
+	case s.MaxMessageSizeMB < 0:
+		return 0
+	case s.MaxMessageSizeMB == 0:
+		return DefaultMaxMessageSizeMB << 20
+	case s.MaxMessageSizeMB >= 4096:
+		return 0 // Beyond what RFC822.SIZE can express
+	default:
+		return uint32(s.MaxMessageSizeMB) << 20
+	}
+}
+
+// SettingsPath returns the path to the settings file
+func SettingsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "settings.json"), nil
+}
+
+// LoadSettings loads the settings, returning defaults if none are saved
+func LoadSettings() (*Settings, error) {
+	path, err := SettingsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Settings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveSettings saves the settings
+func SaveSettings(settings *Settings) error {
+	path, err := SettingsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, data, 0600)
+}
+
+// SaveSmartView adds a smart view, replacing any existing view with the same name
+func SaveSmartView(view SmartView) error {
+	if strings.TrimSpace(view.Name) == "" {
+		return fmt.Errorf("view name is required")
+	}
+	settings, err := LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	for i, v := range settings.SmartViews {
+		if strings.EqualFold(v.Name, view.Name) {
+			settings.SmartViews[i] = view
+			return SaveSettings(settings)
+		}
+	}
+	settings.SmartViews = append(settings.SmartViews, view)
+	return SaveSettings(settings)
+}
+
+// DeleteSmartView removes a smart view by name
+func DeleteSmartView(name string) error {
+	settings, err := LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	var views []SmartView
+	found := false
+	for _, v := range settings.SmartViews {
+		if strings.EqualFold(v.Name, name) {
+			found = true
+			continue
+		}
+		views = append(views, v)
+	}
+	if !found {
+		return fmt.Errorf("view not found: %s", name)
+	}
+
+	settings.SmartViews = views
+	return SaveSettings(settings)
+}
+
+// Matches reports whether a newsletter matches the view's filters.
+// account is the email of the account the newsletter was found in.
+func (v SmartView) Matches(account, category string, tags []string, count int) bool {
+	if v.Account != "" && !strings.EqualFold(v.Account, account) {
+		return false
+	}
+	if count < v.MinCount {
+		return false
+	}
+	if v.MaxCount > 0 && count > v.MaxCount {
+		return false
+	}
+	if len(v.Categories) > 0 && !containsFold(v.Categories, category) {
+		return false
+	}
+	for _, tag := range v.Tags {
+		if !containsFold(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold checks if list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCategoryOverride stores a manual category for sender. An empty
+// category removes the override.
+func SetCategoryOverride(sender, category string) error {
+	settings, err := LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	sender = SenderKey(sender)
+	if category == "" {
+		delete(settings.CategoryOverrides, sender)
+	} else {
+		if settings.CategoryOverrides == nil {
+			settings.CategoryOverrides = map[string]string{}
+		}
+		settings.CategoryOverrides[sender] = category
+	}
+	return SaveSettings(settings)
+}
@@ -0,0 +1,32 @@
+package config
+
+import "fmt"
+
+// Snapshot detail levels, from least to most revealing
+const (
+	SnapshotTotals  = "totals"  // Newsletter and email counts per category only (default)
+	SnapshotDomains = "domains" // Counts per sender domain
+	SnapshotSenders = "senders" // Counts per sender address
+)
+
+// SnapshotDetails lists the valid snapshot detail levels
+var SnapshotDetails = []string{SnapshotTotals, SnapshotDomains, SnapshotSenders}
+
+// ValidateSnapshotDetail checks a detail level name
+func ValidateSnapshotDetail(detail string) error {
+	for _, d := range SnapshotDetails {
+		if detail == d {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown snapshot detail %q: use totals, domains or senders", detail)
+}
+
+// SnapshotDetailLevel returns the configured detail level, SnapshotTotals
+// when none is set
+func (s *Settings) SnapshotDetailLevel() string {
+	if s.SnapshotDetail == "" {
+		return SnapshotTotals
+	}
+	return s.SnapshotDetail
+}
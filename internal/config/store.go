@@ -38,7 +38,7 @@ func ConfigDir() (string, error) {
 	}
 	path := filepath.Join(dir, "newsletter-cli")
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		os.MkdirAll(path, 0700)
+		MkdirAll(path, 0700)
 	}
 	return path, nil
 }
@@ -61,7 +61,7 @@ func Save(cfg Config) error {
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(path, data, 0600)
+	err = WriteFile(path, data, 0600)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// transcriptTimeFormat names transcript files after the session start
+const transcriptTimeFormat = "20060102-150405"
+
+// TranscriptEvent is one action taken during a session, in plain words
+type TranscriptEvent struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// Transcript records the actions of one interactive session. Like the
+// analysis history it shows what the user subscribes to, so it is stored
+// encrypted.
+type Transcript struct {
+	Started time.Time         `json:"started"`
+	Events  []TranscriptEvent `json:"events"`
+
+	mu sync.Mutex
+}
+
+// TranscriptsDir returns the directory session transcripts are stored in
+func TranscriptsDir() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sessions"), nil
+}
+
+// NewTranscript starts the transcript of a session. Nothing is written
+// until the first action is recorded.
+func NewTranscript(started time.Time) *Transcript {
+	return &Transcript{Started: started}
+}
+
+// Record adds an action to the transcript and saves it
+func (t *Transcript) Record(text string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.Events = append(t.Events, TranscriptEvent{Time: time.Now(), Text: text})
+
+	dir, err := TranscriptsDir()
+	if err != nil {
+		return err
+	}
+	if err := MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	encrypted, err := Encrypt(string(data))
+	if err != nil {
+		return err
+	}
+	name := t.Started.Format(transcriptTimeFormat) + ".age"
+	return WriteFile(filepath.Join(dir, name), []byte(encrypted), 0600)
+}
+
+// LoadTranscripts returns the transcripts of sessions started after since,
+// oldest first. Transcripts that cannot be decrypted are skipped.
+func LoadTranscripts(since time.Time) ([]*Transcript, error) {
+	paths, err := transcriptPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var transcripts []*Transcript
+	for _, path := range paths {
+		started, ok := transcriptStart(path)
+		if !ok || started.Before(since) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		plain, err := Decrypt(string(data))
+		if err != nil {
+			continue
+		}
+		var t Transcript
+		if err := json.Unmarshal([]byte(plain), &t); err != nil {
+			continue
+		}
+		transcripts = append(transcripts, &t)
+	}
+	sort.Slice(transcripts, func(i, j int) bool { return transcripts[i].Started.Before(transcripts[j].Started) })
+	return transcripts, nil
+}
+
+// PurgeTranscripts removes the transcripts of sessions started before
+// cutoff, or all of them when cutoff is the zero time, and returns how many
+// were removed
+func PurgeTranscripts(cutoff time.Time) (int, error) {
+	paths, err := transcriptPaths()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, path := range paths {
+		started, ok := transcriptStart(path)
+		if !cutoff.IsZero() && (!ok || !started.Before(cutoff)) {
+			continue
+		}
+		if err := RemoveFile(path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func transcriptPaths() ([]string, error) {
+	dir, err := TranscriptsDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.age"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return paths, nil
+}
+
+// transcriptStart reads the session start from a transcript file name
+func transcriptStart(path string) (time.Time, bool) {
+	name := strings.TrimSuffix(filepath.Base(path), ".age")
+	started, err := time.ParseInLocation(transcriptTimeFormat, name, time.Local)
+	return started, err == nil
+}
@@ -9,7 +9,8 @@ import (
 
 // UnsubscribedNewsletter represents an unsubscribed newsletter
 type UnsubscribedNewsletter struct {
-	Sender         string    `json:"sender"`
+	Sender         string    `json:"sender"`          // Normalized address, see SenderKey
+	Brand          string    `json:"brand,omitempty"` // Brand of the sender, see SenderIdentity
 	UnsubscribedAt time.Time `json:"unsubscribed_at"`
 }
 
@@ -49,6 +50,16 @@ func LoadUnsubscribed() (*UnsubscribedStore, error) {
 		return nil, err
 	}
 
+	// Entries written before senders were normalized keep the case of their
+	// From header
+	for i, n := range store.Newsletters {
+		sender := NewSenderIdentity("", n.Sender)
+		store.Newsletters[i].Sender = sender.Key()
+		if n.Brand == "" {
+			store.Newsletters[i].Brand = sender.Brand
+		}
+	}
+
 	return &store, nil
 }
 
@@ -64,11 +75,11 @@ func SaveUnsubscribed(store *UnsubscribedStore) error {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0600)
+	return WriteFile(path, data, 0600)
 }
 
 // AddUnsubscribed adds a newsletter to the unsubscribed list
-func AddUnsubscribed(sender string) error {
+func AddUnsubscribed(sender SenderIdentity) error {
 	store, err := LoadUnsubscribed()
 	if err != nil {
 		return err
@@ -76,16 +87,17 @@ func AddUnsubscribed(sender string) error {
 
 	// Check if already exists
 	for _, n := range store.Newsletters {
-		if n.Sender == sender {
+		if SenderKey(n.Sender) == sender.Key() {
 			// Already exists, just update timestamp
-			store.Newsletters = removeUnsubscribed(store.Newsletters, sender)
+			store.Newsletters = removeUnsubscribed(store.Newsletters, sender.Key())
 			break
 		}
 	}
 
 	// Add new entry
 	store.Newsletters = append(store.Newsletters, UnsubscribedNewsletter{
-		Sender:         sender,
+		Sender:         sender.Key(),
+		Brand:          sender.Brand,
 		UnsubscribedAt: time.Now(),
 	})
 
@@ -100,7 +112,7 @@ func IsUnsubscribed(sender string) (bool, error) {
 	}
 
 	for _, n := range store.Newsletters {
-		if n.Sender == sender {
+		if SenderKey(n.Sender) == SenderKey(sender) {
 			return true, nil
 		}
 	}
@@ -108,7 +120,8 @@ func IsUnsubscribed(sender string) (bool, error) {
 	return false, nil
 }
 
-// GetUnsubscribedList returns all unsubscribed newsletter senders
+// GetUnsubscribedList returns all unsubscribed newsletter senders, by
+// SenderKey
 func GetUnsubscribedList() (map[string]bool, error) {
 	store, err := LoadUnsubscribed()
 	if err != nil {
@@ -117,7 +130,7 @@ func GetUnsubscribedList() (map[string]bool, error) {
 
 	result := make(map[string]bool)
 	for _, n := range store.Newsletters {
-		result[n.Sender] = true
+		result[SenderKey(n.Sender)] = true
 	}
 
 	return result, nil
@@ -127,7 +140,7 @@ func GetUnsubscribedList() (map[string]bool, error) {
 func removeUnsubscribed(list []UnsubscribedNewsletter, sender string) []UnsubscribedNewsletter {
 	result := []UnsubscribedNewsletter{}
 	for _, n := range list {
-		if n.Sender != sender {
+		if SenderKey(n.Sender) != sender {
 			result = append(result, n)
 		}
 	}
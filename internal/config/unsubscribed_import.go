@@ -0,0 +1,281 @@
+package config
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Column names recognized in unsubscribe exports, by priority
+var (
+	importSenderColumns = []string{"sender", "sender email", "from", "email", "email address", "e-mail", "address", "list", "subscription"}
+	importDateColumns   = []string{"unsubscribed_at", "unsubscribed at", "unsubscribed", "unsub_time", "unsubscribe date", "suppressed_at", "date", "timestamp", "created_at", "created"}
+)
+
+// Time formats found in Google and ESP exports
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"01/02/2006 15:04:05",
+	"01/02/2006",
+	"Jan 2, 2006",
+	time.RFC1123Z,
+}
+
+// ImportResult summarizes an import of unsubscribe history
+type ImportResult struct {
+	Found   int // Senders in the file
+	Added   int // Senders that were not in the unsubscribed list
+	Skipped int // Rows without a usable address
+}
+
+// ParseUnsubscribeExport reads an unsubscribe history export: a CSV file with
+// a sender or email column, as produced by Google's subscription management
+// and the suppression exports of most ESPs, or a JSON array of addresses or
+// objects with such a field
+func ParseUnsubscribeExport(path string) ([]UnsubscribedNewsletter, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM from spreadsheet exports
+
+	trimmed := bytes.TrimSpace(data)
+	if strings.EqualFold(filepath.Ext(path), ".json") || (len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{')) {
+		return parseJSONExport(trimmed)
+	}
+	return parseCSVExport(data)
+}
+
+// parseCSVExport reads a CSV export with a header row
+func parseCSVExport(data []byte) ([]UnsubscribedNewsletter, int, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, 0, nil
+	}
+
+	header := make([]string, len(rows[0]))
+	for i, h := range rows[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+	senderCol := findColumn(header, importSenderColumns)
+	dateCol := findColumn(header, importDateColumns)
+	body := rows[1:]
+	if senderCol == -1 {
+		// No header: use the first column holding an address
+		body = rows
+		senderCol = guessAddressColumn(rows)
+		if senderCol == -1 {
+			return nil, 0, fmt.Errorf("no sender or email column found")
+		}
+	}
+
+	var entries []UnsubscribedNewsletter
+	skipped := 0
+	for _, row := range body {
+		if senderCol >= len(row) {
+			skipped++
+			continue
+		}
+		sender := normalizeImportedAddress(row[senderCol])
+		if sender == "" {
+			skipped++
+			continue
+		}
+		entry := UnsubscribedNewsletter{Sender: sender}
+		if dateCol != -1 && dateCol < len(row) {
+			entry.UnsubscribedAt = parseImportTime(row[dateCol])
+		}
+		entries = append(entries, entry)
+	}
+	return entries, skipped, nil
+}
+
+// parseJSONExport reads a JSON export, either an array or an object holding
+// one
+func parseJSONExport(data []byte) ([]UnsubscribedNewsletter, int, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		var wrapper map[string]json.RawMessage
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return nil, 0, fmt.Errorf("invalid JSON: %w", err)
+		}
+		found := false
+		for _, key := range []string{"newsletters", "unsubscribed", "subscriptions", "suppressions", "members", "data"} {
+			if raw, ok := wrapper[key]; ok && json.Unmarshal(raw, &items) == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, 0, fmt.Errorf("no list of senders found in JSON")
+		}
+	}
+
+	var entries []UnsubscribedNewsletter
+	skipped := 0
+	for _, item := range items {
+		var address string
+		if json.Unmarshal(item, &address) == nil {
+			if sender := normalizeImportedAddress(address); sender != "" {
+				entries = append(entries, UnsubscribedNewsletter{Sender: sender})
+			} else {
+				skipped++
+			}
+			continue
+		}
+
+		var fields map[string]interface{}
+		if json.Unmarshal(item, &fields) != nil {
+			skipped++
+			continue
+		}
+		lower := make(map[string]string, len(fields))
+		for k, v := range fields {
+			switch v := v.(type) {
+			case string:
+				lower[strings.ToLower(k)] = v
+			case float64:
+				lower[strings.ToLower(k)] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+
+		entry := UnsubscribedNewsletter{}
+		for _, key := range importSenderColumns {
+			if sender := normalizeImportedAddress(lower[key]); sender != "" {
+				entry.Sender = sender
+				break
+			}
+		}
+		if entry.Sender == "" {
+			skipped++
+			continue
+		}
+		for _, key := range importDateColumns {
+			if t := parseImportTime(lower[key]); !t.IsZero() {
+				entry.UnsubscribedAt = t
+				break
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, skipped, nil
+}
+
+// ImportUnsubscribed adds imported senders to the unsubscribed list. Senders
+// already in the list keep their entry; entries without a date are recorded
+// as unsubscribed now. skipped is the number of rows the parser dropped,
+// reported along with any entry left without a usable address.
+func ImportUnsubscribed(entries []UnsubscribedNewsletter, skipped int, dryRun bool) (ImportResult, error) {
+	result := ImportResult{Skipped: skipped}
+	store, err := LoadUnsubscribed()
+	if err != nil {
+		return result, err
+	}
+
+	existing := make(map[string]bool, len(store.Newsletters))
+	for _, n := range store.Newsletters {
+		existing[SenderKey(n.Sender)] = true
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		if normalizeImportedAddress(entry.Sender) == "" {
+			result.Skipped++
+			continue
+		}
+		result.Found++
+		sender := NewSenderIdentity("", entry.Sender)
+		if existing[sender.Key()] {
+			continue
+		}
+		existing[sender.Key()] = true
+		entry.Sender, entry.Brand = sender.Key(), sender.Brand
+		if entry.UnsubscribedAt.IsZero() || entry.UnsubscribedAt.After(now) {
+			entry.UnsubscribedAt = now
+		}
+		store.Newsletters = append(store.Newsletters, entry)
+		result.Added++
+	}
+
+	if dryRun || result.Added == 0 {
+		return result, nil
+	}
+	return result, SaveUnsubscribed(store)
+}
+
+// findColumn returns the index of the first header matching one of names in
+// priority order, -1 if none does
+func findColumn(header []string, names []string) int {
+	for _, name := range names {
+		for i, h := range header {
+			if h == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// guessAddressColumn returns the first column of the first row holding an
+// email address
+func guessAddressColumn(rows [][]string) int {
+	for i, cell := range rows[0] {
+		if normalizeImportedAddress(cell) != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeImportedAddress extracts a lowercase address from a cell such as
+// "News <news@example.com>", empty when there is none
+func normalizeImportedAddress(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	if addr, err := mail.ParseAddress(s); err == nil {
+		return SenderKey(addr.Address)
+	}
+	if strings.Count(s, "@") == 1 && !strings.ContainsAny(s, " <>,;") {
+		return SenderKey(s)
+	}
+	return ""
+}
+
+// parseImportTime parses a date in one of the known export formats or as a
+// Unix timestamp, the zero time when it can't
+func parseImportTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t
+		}
+	}
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil && secs > 0 {
+		if secs > 1e12 {
+			return time.UnixMilli(secs) // Milliseconds
+		}
+		return time.Unix(secs, 0)
+	}
+	return time.Time{}
+}
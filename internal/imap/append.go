@@ -0,0 +1,85 @@
+package imap
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// Note is a plain-text message the user stores for themselves
+type Note struct {
+	Subject string
+	Body    string
+}
+
+// AppendNotes stores notes from the user to themselves in folder over a
+// single connection, creating the folder if it does not exist yet. Notes
+// are marked as read so they don't show up as new mail.
+func AppendNotes(server, email, password, folder string, notes []Note) error {
+	if err := config.CheckWritable(); err != nil {
+		return err
+	}
+	c, err := client.DialTLS(server, &tls.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer c.Logout()
+
+	if err := c.Login(email, password); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	if err := ensureFolder(c, folder); err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		now := time.Now()
+		var msg bytes.Buffer
+		fmt.Fprintf(&msg, "From: Newsletter CLI <%s>\r\n", email)
+		fmt.Fprintf(&msg, "To: <%s>\r\n", email)
+		fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", note.Subject))
+		fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+		msg.WriteString("MIME-Version: 1.0\r\n")
+		msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+		msg.WriteString("\r\n")
+		msg.WriteString(note.Body)
+
+		if err := c.Append(folder, []string{imap.SeenFlag}, now, &msg); err != nil {
+			return fmt.Errorf("append to %s failed: %w", folder, err)
+		}
+	}
+	return nil
+}
+
+// ensureFolder creates folder unless the server already lists it
+func ensureFolder(c *client.Client, folder string) error {
+	mailboxes := make(chan *imap.MailboxInfo, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.List("", folder, mailboxes)
+	}()
+
+	exists := false
+	for range mailboxes {
+		exists = true
+	}
+	if err := <-done; err != nil {
+		return fmt.Errorf("listing %s failed: %w", folder, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if err := c.Create(folder); err != nil {
+		return fmt.Errorf("create %s failed: %w", folder, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package imap
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+const (
+	// Bounds for the number of messages requested per FETCH command
+	minFetchBatch     = 10
+	maxFetchBatch     = 500
+	defaultFetchBatch = 50
+
+	// A batch answered faster than this grows the window, slower shrinks it
+	fastBatchLatency = 2 * time.Second
+	slowBatchLatency = 8 * time.Second
+
+	// Number of consecutive failed batches before giving up
+	maxFetchFailures = 3
+
+	fetchTuningFile = "fetch_tuning.json"
+)
+
+// fetchTuning is the persisted batch size learned for a single server
+type fetchTuning struct {
+	BatchSize int       `json:"batch_size"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// fetchTuner adapts the FETCH window size to the observed latency and
+// error rate of a server, so fast servers get large batches and slow
+// shared hosts get small ones.
+type fetchTuner struct {
+	server   string
+	store    BatchSizeStore
+	size     int
+	failures int
+}
+
+var fetchTuningMu sync.Mutex
+
+// newFetchTuner creates a tuner starting from the last size store learned
+// for server. A nil store starts from the default every time.
+func newFetchTuner(server string, store BatchSizeStore) *fetchTuner {
+	t := &fetchTuner{server: server, store: store, size: defaultFetchBatch}
+	if store != nil {
+		if size := store.BatchSize(server); size > 0 {
+			t.size = clampBatch(size)
+		}
+	}
+	return t
+}
+
+// observe records a successful batch and adjusts the window size
+func (t *fetchTuner) observe(count int, elapsed time.Duration) {
+	t.failures = 0
+	// Only trust timings from full batches; the trailing batch is often tiny
+	if count < t.size {
+		return
+	}
+	switch {
+	case elapsed < fastBatchLatency:
+		t.size = clampBatch(t.size * 2)
+	case elapsed > slowBatchLatency:
+		t.size = clampBatch(t.size / 2)
+	}
+}
+
+// failed records a failed batch, shrinks the window and reports whether
+// the batch should be retried
+func (t *fetchTuner) failed() bool {
+	t.failures++
+	t.size = clampBatch(t.size / 2)
+	return t.failures < maxFetchFailures
+}
+
+// save persists the learned window size for the server
+func (t *fetchTuner) save() error {
+	if t.store == nil {
+		return nil
+	}
+	return t.store.SaveBatchSize(t.server, t.size)
+}
+
+// configBatchSizes keeps batch sizes in fetch_tuning.json in the config
+// directory
+type configBatchSizes struct{}
+
+// ConfigBatchSizes returns the store the CLI keeps learned batch sizes in
+func ConfigBatchSizes() BatchSizeStore {
+	return configBatchSizes{}
+}
+
+func (configBatchSizes) BatchSize(server string) int {
+	tunings, err := loadFetchTunings()
+	if err != nil {
+		return 0
+	}
+	return tunings[server].BatchSize
+}
+
+func (configBatchSizes) SaveBatchSize(server string, size int) error {
+	fetchTuningMu.Lock()
+	defer fetchTuningMu.Unlock()
+
+	tunings, err := loadFetchTunings()
+	if err != nil {
+		tunings = map[string]fetchTuning{}
+	}
+	tunings[server] = fetchTuning{BatchSize: size, UpdatedAt: time.Now()}
+
+	path, err := fetchTuningPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(tunings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFile(path, data, 0600)
+}
+
+func clampBatch(size int) int {
+	if size < minFetchBatch {
+		return minFetchBatch
+	}
+	if size > maxFetchBatch {
+		return maxFetchBatch
+	}
+	return size
+}
+
+func fetchTuningPath() (string, error) {
+	dir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fetchTuningFile), nil
+}
+
+func loadFetchTunings() (map[string]fetchTuning, error) {
+	path, err := fetchTuningPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tunings := map[string]fetchTuning{}
+	if err := json.Unmarshal(data, &tunings); err != nil {
+		return nil, err
+	}
+	return tunings, nil
+}
@@ -0,0 +1,153 @@
+package imap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/emersion/go-imap/responses"
+)
+
+// CapabilityFeature explains what an optional server capability means for
+// newsletter-cli
+type CapabilityFeature struct {
+	Capability  string
+	Supported   string // What newsletter-cli does with it
+	Unsupported string // What happens without it
+}
+
+// OptionalCapabilities are the capabilities reported by doctor. Some are
+// listed only to set expectations: the tool works the same without them.
+var OptionalCapabilities = []CapabilityFeature{
+	{"IDLE", "not needed: watch scans on its schedule", "no difference: watch scans on its schedule"},
+	{"CONDSTORE", "not needed: scans search by date", "no difference: scans search by date"},
+	{"QUOTA", "mailbox usage shown by doctor", "mailbox usage unknown"},
+	{"SPECIAL-USE", "junk and archive folders suggested for analysis", "folders are only known by name"},
+	{"MOVE", "not needed: newsletter-cli never moves or deletes mail", "no difference: mail is never moved or deleted"},
+}
+
+// Capabilities is what an IMAP server advertises after login, along with
+// what the optional ones revealed about the account
+type Capabilities struct {
+	All []string // Every advertised capability, sorted
+
+	// Storage in KiB, when the server supports QUOTA. A zero limit means
+	// the server reported no storage limit.
+	QuotaUsed  uint32
+	QuotaLimit uint32
+
+	// Folders by special-use role (\Junk, \Archive), when the server
+	// supports SPECIAL-USE
+	FolderRoles map[string]string
+}
+
+// Has reports whether the server advertised capability name
+func (c *Capabilities) Has(name string) bool {
+	for _, capability := range c.All {
+		if strings.EqualFold(capability, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestedRoles are the special-use folders newsletters may end up in.
+// \All is left out: it holds INBOX too, so its messages would be counted
+// twice.
+var suggestedRoles = []string{imap.JunkAttr, imap.ArchiveAttr}
+
+// CheckCapabilities logs in and reports the server's capabilities, querying
+// the quota and folder roles when the server supports them. Once the login
+// succeeded the returned Capabilities is non-nil, even along with an error,
+// so callers can tell a failed login from a failed query.
+func CheckCapabilities(email, password, server string) (*Capabilities, error) {
+	c, err := client.DialTLS(server, &tls.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer c.Logout()
+
+	if err := c.Login(email, password); err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+
+	caps := &Capabilities{FolderRoles: map[string]string{}}
+
+	// Servers often advertise more once logged in, so ask again
+	advertised, err := c.Capability()
+	if err != nil {
+		return caps, fmt.Errorf("capability failed: %w", err)
+	}
+
+	for name := range advertised {
+		caps.All = append(caps.All, name)
+	}
+	sort.Strings(caps.All)
+	log.Printf("IMAP capabilities of %s: %s", server, strings.Join(caps.All, " "))
+
+	if caps.Has("QUOTA") {
+		if err := readQuota(c, caps); err != nil {
+			log.Printf("Quota lookup failed: %v", err)
+		}
+	}
+
+	if caps.Has("SPECIAL-USE") {
+		if err := readFolderRoles(c, caps); err != nil {
+			log.Printf("Folder role lookup failed: %v", err)
+		}
+	}
+
+	return caps, nil
+}
+
+// readQuota runs GETQUOTAROOT INBOX and keeps the STORAGE resource
+func readQuota(c *client.Client, caps *Capabilities) error {
+	cmd := &imap.Command{Name: "GETQUOTAROOT", Arguments: []interface{}{imap.FormatMailboxName("INBOX")}}
+	status, err := c.Execute(cmd, responses.HandlerFunc(func(resp imap.Resp) error {
+		name, fields, ok := imap.ParseNamedResp(resp)
+		if !ok || name != "QUOTA" || len(fields) < 2 {
+			return responses.ErrUnhandled
+		}
+		resources, ok := fields[1].([]interface{})
+		if !ok {
+			return responses.ErrUnhandled
+		}
+		// Resources come as (NAME usage limit) triples
+		for i := 0; i+2 < len(resources); i += 3 {
+			if resource, _ := resources[i].(string); !strings.EqualFold(resource, "STORAGE") {
+				continue
+			}
+			caps.QuotaUsed, _ = imap.ParseNumber(resources[i+1])
+			caps.QuotaLimit, _ = imap.ParseNumber(resources[i+2])
+		}
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+	return status.Err()
+}
+
+// readFolderRoles lists the folders and records the special-use ones
+func readFolderRoles(c *client.Client, caps *Capabilities) error {
+	mailboxes := make(chan *imap.MailboxInfo, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.List("", "*", mailboxes)
+	}()
+
+	for mailbox := range mailboxes {
+		for _, attr := range mailbox.Attributes {
+			for _, role := range suggestedRoles {
+				if strings.EqualFold(attr, role) {
+					caps.FolderRoles[role] = mailbox.Name
+				}
+			}
+		}
+	}
+	return <-done
+}
@@ -33,7 +33,17 @@ func ConnectIMAP(email, password, server string) error {
 		}
 	}
 
-	log.Printf("Connecting to IMAP server: %s", server)
+	return CheckLogin(email, password, server, log.Default())
+}
+
+// CheckLogin connects and authenticates to server and lists the mailboxes
+// to confirm the account is usable. A nil logger discards messages.
+func CheckLogin(email, password, server string, logger Logger) error {
+	if logger == nil {
+		logger = nopLogger{}
+	}
+
+	logger.Printf("Connecting to IMAP server: %s", server)
 	c, err := client.DialTLS(server, &tls.Config{})
 	if err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
@@ -58,13 +68,23 @@ func ConnectIMAP(email, password, server string) error {
 		return fmt.Errorf("listing mailboxes failed: %w", err)
 	}
 
-	log.Printf("✅ IMAP login successful. Found %d mailboxes.", count)
+	logger.Printf("✅ IMAP login successful. Found %d mailboxes.", count)
 	return nil
 }
 
 // DiscoverIMAPServer discovers the IMAP server using DNS autodiscover
 // This is a public function for use by the UI
 func DiscoverIMAPServer(email string) (string, error) {
+	return DiscoverServer(email, log.Default())
+}
+
+// DiscoverServer discovers the IMAP server for email, reporting how it was
+// found to logger. A nil logger discards messages.
+func DiscoverServer(email string, logger Logger) (string, error) {
+	if logger == nil {
+		logger = nopLogger{}
+	}
+
 	// Extract domain from email
 	parts := strings.Split(email, "@")
 	if len(parts) != 2 {
@@ -74,25 +94,25 @@ func DiscoverIMAPServer(email string) (string, error) {
 
 	// Try known providers first (faster)
 	if server := getKnownProviderServer(domain); server != "" {
-		log.Printf("Using known provider server: %s", server)
+		logger.Printf("Using known provider server: %s", server)
 		return server, nil
 	}
 
 	// Try DNS SRV records (RFC 6186)
 	if server, err := discoverSRV(domain); err == nil {
-		log.Printf("Discovered IMAP server via SRV record: %s", server)
+		logger.Printf("Discovered IMAP server via SRV record: %s", server)
 		return server, nil
 	}
 
 	// Try autoconfig/autodiscover endpoints
 	if server, err := discoverAutoconfig(domain, email); err == nil {
-		log.Printf("Discovered IMAP server via autoconfig: %s", server)
+		logger.Printf("Discovered IMAP server via autoconfig: %s", server)
 		return server, nil
 	}
 
 	// Try common hostname patterns
 	if server := tryCommonPatterns(domain); server != "" {
-		log.Printf("Discovered IMAP server via pattern: %s", server)
+		logger.Printf("Discovered IMAP server via pattern: %s", server)
 		return server, nil
 	}
 
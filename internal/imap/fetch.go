@@ -3,8 +3,9 @@ package imap
 import (
 	"bytes"
 	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"net/mail"
 	"regexp"
 	"strings"
@@ -12,59 +13,294 @@ import (
 
 	"github.com/emersion/go-imap"
 	"github.com/emersion/go-imap/client"
+	"github.com/loickal/newsletter-cli/internal/config"
 )
 
+// ErrNoEmails is returned when the search window contains no messages
+var ErrNoEmails = errors.New("no emails found")
+
 type NewsletterStat struct {
-	Sender      string
-	Count       int
-	Unsubscribe string
+	Sender        config.SenderIdentity
+	Count         int
+	Unsubscribe   string
+	Preferences   string // Preference center link, to reduce frequency instead
+	Transactional bool   // Most messages look like receipts, resets or security alerts
+
+	// Messages whose body was scanned, and how many of those carried an
+	// open-tracking pixel or click-tracking links. Large messages are only
+	// analyzed from their headers and not scanned.
+	ScannedEmails int
+	TrackedEmails int
+
+	// Engagement, from the IMAP flags: messages marked as read, answered
+	// and flagged (starred)
+	ReadEmails    int
+	RepliedEmails int
+	StarredEmails int
+
+	// Arrival histograms in local time: messages per hour of day and per
+	// weekday (index 0 is Sunday, as in time.Weekday)
+	ByHour    [24]int
+	ByWeekday [7]int
+}
+
+// ReadRate is the share of the sender's messages that were opened
+func (s NewsletterStat) ReadRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.ReadEmails) / float64(s.Count)
+}
+
+// TrackingHeavy reports whether most scanned messages of the sender carried
+// open or click tracking
+func (s NewsletterStat) TrackingHeavy() bool {
+	return s.ScannedEmails > 0 && s.TrackedEmails*2 >= s.ScannedEmails
+}
+
+// FolderStat summarizes what was found in a single analyzed folder
+type FolderStat struct {
+	Name             string
+	Emails           int   // Messages in the search window
+	Newsletters      int   // Distinct newsletter senders
+	NewsletterEmails int   // Messages from newsletter senders
+	LargeMessages    int   // Messages above the size limit, analyzed from headers only
+	Err              error // Set when the folder could not be analyzed
+}
+
+// senderTally accumulates per-sender counts while fetching
+type senderTally struct {
+	sender        config.SenderIdentity
+	count         int
+	transactional int
+	link          string
+	preferences   string
+	scanned       int
+	tracked       int
+	read          int
+	replied       int
+	starred       int
+	byHour        [24]int
+	byWeekday     [7]int
 }
 
 // FetchNewsletterStats connects to IMAP, fetches messages and groups newsletters.
+// A zero since searches the whole mailbox.
 func FetchNewsletterStats(server, email, password string, since time.Time) ([]NewsletterStat, error) {
-	log.Println("📬 Connecting to IMAP for analysis...")
+	stats, _, err := FetchFolderStats(server, email, password, since, []string{"INBOX"})
+	return stats, err
+}
+
+// FetchFolderStats analyzes each of the given folders with the options
+// from the settings, see SettingsFetchOptions
+func FetchFolderStats(server, email, password string, since time.Time, folders []string) ([]NewsletterStat, []FolderStat, error) {
+	return FetchFolderStatsWith(server, email, password, since, SettingsFetchOptions(folders))
+}
+
+// FetchFolderStatsWith analyzes each folder in opts and returns the
+// newsletters merged across folders along with a per-folder summary.
+// Folders that fail to open are reported in their FolderStat and skipped.
+func FetchFolderStatsWith(server, email, password string, since time.Time, opts FetchOptions) ([]NewsletterStat, []FolderStat, error) {
+	logger := opts.logger()
+	logger.Printf("📬 Connecting to IMAP for analysis...")
 	c, err := client.DialTLS(server, &tls.Config{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect: %w", err)
 	}
 	defer c.Logout()
 
 	if err := c.Login(email, password); err != nil {
-		return nil, fmt.Errorf("login failed: %w", err)
+		return nil, nil, fmt.Errorf("login failed: %w", err)
 	}
 
-	_, err = c.Select("INBOX", false)
-	if err != nil {
-		return nil, fmt.Errorf("select INBOX failed: %w", err)
+	folders := opts.Folders
+	if len(folders) == 0 {
+		folders = []string{"INBOX"}
+	}
+
+	stats := map[string]senderTally{}
+	var folderStats []FolderStat
+	totalEmails := 0
+
+	// Fetch in windows sized to the server's responsiveness
+	tuner := newFetchTuner(server, opts.BatchSizes)
+	for _, folder := range folders {
+		fs, err := fetchFolder(c, tuner, logger, folder, email, since, opts.MaxMessageSize, !opts.SkipBodyScan, stats)
+		if err != nil {
+			// A single failing folder is reported, unless it is the only one
+			if len(folders) == 1 {
+				return nil, nil, err
+			}
+			logger.Printf("Skipping folder %s: %v", folder, err)
+			fs.Err = err
+		}
+		if fs.LargeMessages > 0 {
+			logger.Printf("Read only headers of %d large message(s) in %s", fs.LargeMessages, folder)
+		}
+		totalEmails += fs.Emails
+		folderStats = append(folderStats, fs)
+	}
+	// The analysis itself succeeded, the next one just starts from the default
+	if err := tuner.save(); err != nil && !errors.Is(err, config.ErrReadOnly) {
+		logger.Printf("Could not save the fetch batch size: %v", err)
+	}
+
+	if totalEmails == 0 {
+		if since.IsZero() {
+			return nil, folderStats, fmt.Errorf("%w in %s", ErrNoEmails, strings.Join(folders, ", "))
+		}
+		return nil, folderStats, fmt.Errorf("%w since %s", ErrNoEmails, since.Format("2006-01-02"))
+	}
+
+	var results []NewsletterStat
+	for _, s := range stats {
+		results = append(results, NewsletterStat{
+			Sender:        s.sender,
+			Count:         s.count,
+			Unsubscribe:   s.link,
+			Preferences:   s.preferences,
+			Transactional: s.transactional*2 >= s.count,
+			ScannedEmails: s.scanned,
+			TrackedEmails: s.tracked,
+			ReadEmails:    s.read,
+			RepliedEmails: s.replied,
+			StarredEmails: s.starred,
+			ByHour:        s.byHour,
+			ByWeekday:     s.byWeekday,
+		})
+	}
+	return results, folderStats, nil
+}
+
+// fetchFolder analyzes one folder, merging newsletter senders into stats.
+// Messages larger than maxSize bytes are analyzed from their headers only;
+// a maxSize of 0 always downloads the full message. Without scanBodies, no
+// message body is downloaded.
+func fetchFolder(c *client.Client, tuner *fetchTuner, logger Logger, folder, email string, since time.Time, maxSize uint32, scanBodies bool, stats map[string]senderTally) (FolderStat, error) {
+	fs := FolderStat{Name: folder}
+
+	// EXAMINE in read-only mode, so the server can't change anything either
+	if _, err := c.Select(folder, config.ReadOnly()); err != nil {
+		return fs, fmt.Errorf("select %s failed: %w", folder, err)
 	}
 
 	criteria := imap.NewSearchCriteria()
-	criteria.Since = since
+	if !since.IsZero() {
+		criteria.Since = since
+	}
 	ids, err := c.Search(criteria)
 	if err != nil {
-		return nil, fmt.Errorf("search failed: %w", err)
+		return fs, fmt.Errorf("search failed: %w", err)
 	}
-	if len(ids) == 0 {
-		return nil, fmt.Errorf("no emails found since %s", since.Format("2006-01-02"))
+	fs.Emails = len(ids)
+
+	senders := map[string]bool{}
+	for start := 0; start < len(ids); {
+		end := start + tuner.size
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		began := time.Now()
+		batch, large, err := fetchBatch(c, ids[start:end], email, maxSize, scanBodies)
+		if err != nil {
+			if tuner.failed() {
+				logger.Printf("Fetch of %d messages failed, retrying with %d: %v", end-start, tuner.size, err)
+				continue
+			}
+			return fs, fmt.Errorf("fetch failed: %w", err)
+		}
+		tuner.observe(end-start, time.Since(began))
+		fs.LargeMessages += large
+
+		// Only merge once the whole batch succeeded so retries don't double count
+		for _, m := range batch {
+			// Keyed by identity, so differently cased From headers of one
+			// sender are counted together
+			key := m.sender.Key()
+			entry, seen := stats[key]
+			if !seen {
+				entry.sender = m.sender
+			}
+			entry.count++
+			if m.transactional {
+				entry.transactional++
+			}
+			if m.scanned {
+				entry.scanned++
+				if m.tracked {
+					entry.tracked++
+				}
+			}
+			if m.read {
+				entry.read++
+			}
+			if m.replied {
+				entry.replied++
+			}
+			if m.starred {
+				entry.starred++
+			}
+			if entry.link == "" && m.link != "" {
+				entry.link = m.link
+			}
+			if entry.preferences == "" && m.preferences != "" {
+				entry.preferences = m.preferences
+			}
+			if entry.sender.Name == "" && m.sender.Name != "" {
+				entry.sender.Name = m.sender.Name
+			}
+			if !m.date.IsZero() {
+				local := m.date.Local()
+				entry.byHour[local.Hour()]++
+				entry.byWeekday[local.Weekday()]++
+			}
+			stats[key] = entry
+			senders[key] = true
+			fs.NewsletterEmails++
+		}
+		start = end
 	}
+	fs.Newsletters = len(senders)
+	return fs, nil
+}
+
+// fetchedMessage is a newsletter message extracted from a FETCH batch
+type fetchedMessage struct {
+	sender        config.SenderIdentity
+	link          string
+	preferences   string
+	transactional bool
+	scanned       bool // The body was downloaded and checked for trackers
+	tracked       bool
+	read          bool
+	replied       bool
+	starred       bool
+	date          time.Time
+}
 
+// fetchBatch fetches a single window of messages and returns the ones
+// that look like newsletters, along with how many of them were too large
+// to download in full. Envelopes and sizes are fetched first so only
+// newsletter candidates are downloaded, and only their headers when they
+// exceed maxSize or scanBodies is false.
+func fetchBatch(c *client.Client, ids []uint32, email string, maxSize uint32, scanBodies bool) ([]fetchedMessage, int, error) {
 	seqset := new(imap.SeqSet)
 	seqset.AddNum(ids...)
 
-	messages := make(chan *imap.Message, 10)
-	done := make(chan error, 1)
-	go func() {
-		section := &imap.BodySectionName{}
-		done <- c.Fetch(seqset, []imap.FetchItem{imap.FetchEnvelope, section.FetchItem()}, messages)
-	}()
+	envelopes, err := fetchMessages(c, seqset, []imap.FetchItem{imap.FetchEnvelope, imap.FetchRFC822Size, imap.FetchFlags})
+	if err != nil {
+		return nil, 0, err
+	}
 
-	type seen struct {
-		count int
-		link  string
+	flags := map[uint32][]string{}
+	for _, msg := range envelopes {
+		flags[msg.SeqNum] = msg.Flags
 	}
-	stats := map[string]seen{}
 
-	for msg := range messages {
+	full, large := new(imap.SeqSet), new(imap.SeqSet)
+	largeCount := 0
+	for _, msg := range envelopes {
 		if msg.Envelope == nil || len(msg.Envelope.From) == 0 {
 			continue
 		}
@@ -75,36 +311,118 @@ func FetchNewsletterStats(server, email, password string, since time.Time) ([]Ne
 		if !isLikelyNewsletter(from, msg.Envelope.Subject) {
 			continue
 		}
+		switch {
+		case !scanBodies:
+			large.AddNum(msg.SeqNum)
+		case maxSize > 0 && msg.Size > maxSize:
+			large.AddNum(msg.SeqNum)
+			largeCount++
+		default:
+			full.AddNum(msg.SeqNum)
+		}
+	}
+
+	var candidates []*imap.Message
+	if !full.Empty() {
+		// Peek, so analyzing doesn't mark newsletters as read and skew the read rate
+		section := &imap.BodySectionName{Peek: true}
+		msgs, err := fetchMessages(c, full, []imap.FetchItem{imap.FetchEnvelope, section.FetchItem()})
+		if err != nil {
+			return nil, 0, err
+		}
+		candidates = append(candidates, msgs...)
+	}
+	if !large.Empty() {
+		section := headerSection()
+		msgs, err := fetchMessages(c, large, []imap.FetchItem{imap.FetchEnvelope, section.FetchItem()})
+		if err != nil {
+			return nil, 0, err
+		}
+		candidates = append(candidates, msgs...)
+	}
+
+	var results []fetchedMessage
+	for _, msg := range candidates {
+		if msg.Envelope == nil || len(msg.Envelope.From) == 0 {
+			continue
+		}
+		from := msg.Envelope.From[0].Address()
 
 		// Parse raw header for List-Unsubscribe
-		var link string
-		if r := msg.GetBody(&imap.BodySectionName{}); r != nil {
+		var link, preferences string
+		var scanned, tracked bool
+		var header mail.Header
+		r := msg.GetBody(&imap.BodySectionName{})
+		if r == nil {
+			r = msg.GetBody(headerSection())
+		}
+		if r != nil {
 			buf := new(bytes.Buffer)
 			buf.ReadFrom(r)
 			m, err := mail.ReadMessage(bytes.NewReader(buf.Bytes()))
 			if err == nil {
+				header = m.Header
 				lh := m.Header.Get("List-Unsubscribe")
 				link = extractUnsubscribeLink(lh)
+				body, _ := io.ReadAll(m.Body) // Empty for header-only fetches
+				text := bodyText(m.Header, body)
+				preferences = extractPreferenceLink(m.Header, text)
+				if len(body) > 0 {
+					scanned = true
+					tracked = hasTracking(text)
+				}
 			}
 		}
 
-		entry := stats[from]
-		entry.count++
-		if entry.link == "" && link != "" {
-			entry.link = link
+		results = append(results, fetchedMessage{
+			sender:        config.NewSenderIdentity(msg.Envelope.From[0].PersonalName, from),
+			link:          link,
+			preferences:   preferences,
+			scanned:       scanned,
+			tracked:       tracked,
+			read:          hasFlag(flags[msg.SeqNum], imap.SeenFlag),
+			replied:       hasFlag(flags[msg.SeqNum], imap.AnsweredFlag),
+			starred:       hasFlag(flags[msg.SeqNum], imap.FlaggedFlag),
+			transactional: isLikelyTransactional(from, msg.Envelope.Subject, header),
+			date:          msg.Envelope.Date,
+		})
+	}
+	return results, largeCount, nil
+}
+
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if strings.EqualFold(f, flag) {
+			return true
 		}
-		stats[from] = entry
 	}
+	return false
+}
 
-	if err := <-done; err != nil {
-		return nil, fmt.Errorf("fetch failed: %w", err)
+// headerSection is BODY.PEEK[HEADER], the headers of a message without its body
+func headerSection() *imap.BodySectionName {
+	return &imap.BodySectionName{
+		BodyPartName: imap.BodyPartName{Specifier: imap.HeaderSpecifier},
+		Peek:         true,
 	}
+}
 
-	var results []NewsletterStat
-	for sender, s := range stats {
-		results = append(results, NewsletterStat{Sender: sender, Count: s.count, Unsubscribe: s.link})
+// fetchMessages runs a FETCH command and collects the returned messages
+func fetchMessages(c *client.Client, seqset *imap.SeqSet, items []imap.FetchItem) ([]*imap.Message, error) {
+	messages := make(chan *imap.Message, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Fetch(seqset, items, messages)
+	}()
+
+	var result []*imap.Message
+	for msg := range messages {
+		result = append(result, msg)
+	}
+	if err := <-done; err != nil {
+		return nil, err
 	}
-	return results, nil
+	return result, nil
 }
 
 func isLikelyNewsletter(from, subject string) bool {
@@ -0,0 +1,63 @@
+package imap
+
+import (
+	"log"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// Logger receives progress messages. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// nopLogger discards all messages
+type nopLogger struct{}
+
+func (nopLogger) Printf(string, ...any) {}
+
+// BatchSizeStore keeps the FETCH batch size learned for each server
+// between analyses
+type BatchSizeStore interface {
+	// BatchSize returns the size last saved for server, 0 if none
+	BatchSize(server string) int
+	SaveBatchSize(server string, size int) error
+}
+
+// FetchOptions configures FetchFolderStatsWith. The zero value analyzes
+// INBOX, downloads every message in full, logs nothing and keeps nothing
+// between calls.
+type FetchOptions struct {
+	Folders []string // INBOX when empty
+	// MaxMessageSize is the size in bytes above which messages are
+	// analyzed from their headers only, 0 for no limit
+	MaxMessageSize uint32
+	SkipBodyScan   bool
+	Logger         Logger         // Discards messages when nil
+	BatchSizes     BatchSizeStore // Batch sizes are learned per call when nil
+}
+
+// SettingsFetchOptions returns the options the CLI analyzes with: the
+// message size limit and body scanning from the settings, the standard
+// logger and batch sizes kept in the config directory
+func SettingsFetchOptions(folders []string) FetchOptions {
+	settings, err := config.LoadSettings()
+	if err != nil {
+		settings = &config.Settings{}
+	}
+	return FetchOptions{
+		Folders:        folders,
+		MaxMessageSize: settings.MaxMessageSize(),
+		SkipBodyScan:   settings.SkipBodyScan,
+		Logger:         log.Default(),
+		BatchSizes:     ConfigBatchSizes(),
+	}
+}
+
+// logger returns the configured logger or one that discards messages
+func (o FetchOptions) logger() Logger {
+	if o.Logger == nil {
+		return nopLogger{}
+	}
+	return o.Logger
+}
@@ -0,0 +1,144 @@
+package imap
+
+import (
+	"bytes"
+	"encoding/base64"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+)
+
+// preferenceKeywords appear in the URLs and link texts of preference
+// centers, where subscribers change topics or frequency instead of leaving
+var preferenceKeywords = []string{
+	"preference", "manage your subscription", "manage subscription",
+	"manage-subscription", "subscription-center", "subscription center",
+	"email settings", "email-settings", "frequency", "profile_center",
+	"profile-center", "update your profile", "manage your email",
+}
+
+var (
+	reAnchor   = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*["']([^"']+)["'][^>]*>(.*?)</a>`)
+	reTag      = regexp.MustCompile(`<[^>]*>`)
+	rePlainURL = regexp.MustCompile(`https?://[^\s<>"')\]]+`)
+)
+
+// maxPartDepth bounds how deeply nested multipart bodies are walked
+const maxPartDepth = 5
+
+// bodyText returns the decoded text and HTML parts of a message body,
+// joined. Multipart bodies are walked part by part and base64 or
+// quoted-printable transfer encodings undone, which would otherwise hide or
+// mangle the links.
+func bodyText(header mail.Header, body []byte) string {
+	var b strings.Builder
+	writePartText(&b, textproto.MIMEHeader(header), body, 0)
+	return b.String()
+}
+
+// writePartText appends the text of one MIME part, or of its subparts
+func writePartText(b *strings.Builder, header textproto.MIMEHeader, body []byte, depth int) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain" // The default without a usable Content-Type
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if depth >= maxPartDepth || params["boundary"] == "" {
+			return
+		}
+		r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			// Raw, so the transfer encoding is undone here for every part
+			part, err := r.NextRawPart()
+			if err != nil {
+				return
+			}
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return
+			}
+			writePartText(b, part.Header, data, depth+1)
+		}
+	}
+	if !strings.HasPrefix(mediaType, "text/") {
+		return
+	}
+	b.WriteString(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	b.WriteString("\n")
+}
+
+// decodeTransferEncoding undoes a Content-Transfer-Encoding. What can't be
+// decoded is returned as far as it could be.
+func decodeTransferEncoding(encoding string, body []byte) string {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		cleaned := strings.Map(func(r rune) rune {
+			if r == '\r' || r == '\n' || r == ' ' || r == '\t' {
+				return -1
+			}
+			return r
+		}, string(body))
+		decoded, err := base64.StdEncoding.DecodeString(cleaned)
+		if err != nil {
+			// Some senders drop the padding
+			decoded, _ = base64.RawStdEncoding.DecodeString(strings.TrimRight(cleaned, "="))
+		}
+		return string(decoded)
+	case "quoted-printable":
+		decoded, _ := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(body)))
+		return string(decoded)
+	}
+	return string(body)
+}
+
+// extractPreferenceLink looks for a preference center link, first among the
+// List-Unsubscribe links, then in the links of the message body text
+func extractPreferenceLink(header mail.Header, text string) string {
+	if header != nil {
+		for _, m := range reLink.FindAllStringSubmatch(header.Get("List-Unsubscribe"), -1) {
+			if strings.HasPrefix(m[1], "http") && isPreferenceText(m[1]) {
+				return m[1]
+			}
+		}
+	}
+	if text == "" {
+		return ""
+	}
+
+	for _, m := range reAnchor.FindAllStringSubmatch(text, -1) {
+		link := html.UnescapeString(strings.TrimSpace(m[1]))
+		if !strings.HasPrefix(link, "http") {
+			continue
+		}
+		label := reTag.ReplaceAllString(m[2], "")
+		if isPreferenceText(label) || isPreferenceText(link) {
+			return link
+		}
+	}
+	// Plain text parts
+	for _, link := range rePlainURL.FindAllString(text, -1) {
+		if isPreferenceText(link) {
+			return link
+		}
+	}
+	return ""
+}
+
+// isPreferenceText reports whether a URL or link text looks like it leads
+// to a preference center
+func isPreferenceText(s string) bool {
+	s = strings.ToLower(s)
+	for _, k := range preferenceKeywords {
+		if strings.Contains(s, k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package imap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+)
+
+// SenderMessage identifies a message received from a sender
+type SenderMessage struct {
+	Folder    string    `json:"folder"`
+	Date      time.Time `json:"date"`
+	Subject   string    `json:"subject"`
+	MessageID string    `json:"message_id,omitempty"`
+}
+
+// FindMessagesFrom lists the messages each sender sent on or after its date
+// in senders, searching folders (INBOX when empty) over a single connection
+func FindMessagesFrom(server, email, password string, folders []string, senders map[string]time.Time) (map[string][]SenderMessage, error) {
+	c, err := client.DialTLS(server, &tls.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer c.Logout()
+
+	if err := c.Login(email, password); err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+
+	if len(folders) == 0 {
+		folders = []string{"INBOX"}
+	}
+
+	found := make(map[string][]SenderMessage)
+	for _, folder := range folders {
+		if _, err := c.Select(folder, true); err != nil {
+			return nil, fmt.Errorf("failed to select %s: %w", folder, err)
+		}
+
+		for sender, since := range senders {
+			criteria := imap.NewSearchCriteria()
+			criteria.Header.Add("From", sender)
+			criteria.Since = since
+			ids, err := c.Search(criteria)
+			if err != nil {
+				return nil, fmt.Errorf("search in %s failed: %w", folder, err)
+			}
+			if len(ids) == 0 {
+				continue
+			}
+
+			seqset := new(imap.SeqSet)
+			seqset.AddNum(ids...)
+			msgs, err := fetchMessages(c, seqset, []imap.FetchItem{imap.FetchEnvelope})
+			if err != nil {
+				return nil, err
+			}
+			for _, msg := range msgs {
+				if msg.Envelope == nil || msg.Envelope.Date.Before(since) {
+					continue // SINCE only compares dates, not times
+				}
+				found[sender] = append(found[sender], SenderMessage{
+					Folder:    folder,
+					Date:      msg.Envelope.Date,
+					Subject:   msg.Envelope.Subject,
+					MessageID: msg.Envelope.MessageId,
+				})
+			}
+		}
+	}
+	return found, nil
+}
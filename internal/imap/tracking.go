@@ -0,0 +1,86 @@
+package imap
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Open-tracking pixel URLs of common ESPs and tracking services
+var pixelPatterns = []string{
+	"/track/open", "/wf/open", "/e/o/", "/o/eJ", "/open.php", "/open.aspx",
+	"/trk/open", "/pixel", "/beacon", "open.substack.com", "mandrillapp.com/track",
+	"mailtrack.io", "getnotify.com", "t.hubspotemail.net", "track.hubspot.com",
+	"/imp?", "/openmail", "/emimp/", "/ss/o/", "list-manage.com/track/open",
+}
+
+// Click-tracking redirect hosts and paths that wrap the real link target
+var clickPatterns = []string{
+	"/track/click", "/ls/click", "/wf/click", "/c/eJ", "/ss/c/", "/trk/click",
+	"ct.sendgrid.net", "click.", "clicks.", "links.", "trk.", "track.",
+	"r20.rs6.net", "mandrillapp.com/track/click", "hubspotlinks.com",
+	"klclick", "mailchi.mp", "/redirect?", "/r/?", "email.mg.",
+}
+
+var (
+	reImg       = regexp.MustCompile(`(?is)<img\s[^>]*>`)
+	reSrc       = regexp.MustCompile(`(?is)\bsrc\s*=\s*["']([^"']+)["']`)
+	reTinyImage = regexp.MustCompile(`(?is)\b(width|height)\s*=\s*["']?\s*[01](px)?\s*["']?[\s/>]|\b(width|height)\s*:\s*[01]px`)
+	reHref      = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*["'](https?://[^"']+)["']`)
+)
+
+// hasTracking reports whether an HTML body carries an open-tracking pixel or
+// wraps most of its links in click trackers
+func hasTracking(body string) bool {
+	for _, img := range reImg.FindAllString(body, -1) {
+		src := reSrc.FindStringSubmatch(img)
+		if src == nil {
+			continue
+		}
+		if reTinyImage.MatchString(img) || containsAny(strings.ToLower(src[1]), pixelPatterns) {
+			return true
+		}
+	}
+
+	links := reHref.FindAllStringSubmatch(body, -1)
+	wrapped := 0
+	for _, m := range links {
+		if isClickTracker(m[1]) {
+			wrapped++
+		}
+	}
+	return len(links) > 0 && wrapped*2 >= len(links)
+}
+
+// isClickTracker reports whether a link goes through a click-tracking redirect
+func isClickTracker(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	target := host + strings.ToLower(u.EscapedPath())
+	if u.RawQuery != "" {
+		target += "?"
+	}
+	for _, p := range clickPatterns {
+		if strings.HasSuffix(p, ".") {
+			if strings.HasPrefix(host, p) {
+				return true
+			}
+		} else if strings.Contains(target, strings.ToLower(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny reports whether s contains one of the patterns
+func containsAny(s string, patterns []string) bool {
+	for _, p := range patterns {
+		if strings.Contains(s, strings.ToLower(p)) {
+			return true
+		}
+	}
+	return false
+}
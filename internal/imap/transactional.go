@@ -0,0 +1,61 @@
+package imap
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// Subject keywords typical of receipts, account and security mail
+var transactionalSubjectKeywords = []string{
+	"receipt", "invoice", "order confirmation", "your order", "has shipped",
+	"shipping confirmation", "delivery", "payment", "password", "reset your",
+	"verify your", "verification code", "security alert", "sign-in", "signin",
+	"login attempt", "new login", "two-factor", "2fa", "one-time code",
+	"confirm your email", "account activity", "statement", "booking confirmation",
+	"reservation",
+}
+
+// Sender local parts that are used almost exclusively for transactional mail
+var transactionalSenderPrefixes = []string{
+	"receipts@", "receipt@", "billing@", "invoice@", "invoices@", "orders@",
+	"order@", "security@", "account@", "accounts@", "verify@", "auth@",
+	"payments@", "shipping@",
+}
+
+// isLikelyTransactional uses header and keyword heuristics to spot receipts,
+// password resets and security alerts. Mailing-list headers count against it,
+// since real transactional mail is rarely sent through list software.
+func isLikelyTransactional(from, subject string, header mail.Header) bool {
+	score := 0
+
+	lowerSubject := strings.ToLower(subject)
+	for _, k := range transactionalSubjectKeywords {
+		if strings.Contains(lowerSubject, k) {
+			score += 2
+			break
+		}
+	}
+
+	lowerFrom := strings.ToLower(from)
+	for _, p := range transactionalSenderPrefixes {
+		if strings.HasPrefix(lowerFrom, p) {
+			score += 2
+			break
+		}
+	}
+
+	if header != nil {
+		// Auto-generated mail that is not bulk is a strong transactional hint
+		if strings.HasPrefix(strings.ToLower(header.Get("Auto-Submitted")), "auto-generated") {
+			score++
+		}
+		if header.Get("List-Id") != "" {
+			score -= 2
+		}
+		if strings.EqualFold(header.Get("Precedence"), "bulk") || strings.EqualFold(header.Get("Precedence"), "list") {
+			score--
+		}
+	}
+
+	return score >= 2
+}
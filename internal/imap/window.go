@@ -0,0 +1,60 @@
+package imap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWindowDays caps how far back a relative window can reach (10 years)
+const maxWindowDays = 3650
+
+// ParseSearchWindow converts a period such as "30", "90d", "2w", "3m", "1y"
+// or "all" into the date to search from. A bare number is a number of days.
+// "all" returns the zero time, meaning the whole mailbox is searched.
+func ParseSearchWindow(input string) (time.Time, error) {
+	return parseSearchWindow(input, time.Now())
+}
+
+func parseSearchWindow(input string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	if s == "" {
+		return time.Time{}, fmt.Errorf("enter a period like 30, 2w, 3m or all")
+	}
+	if s == "all" {
+		return time.Time{}, nil
+	}
+
+	unit := s[len(s)-1]
+	numStr := s
+	if unit < '0' || unit > '9' {
+		numStr = s[:len(s)-1]
+	} else {
+		unit = 'd'
+	}
+
+	n, err := strconv.Atoi(numStr)
+	if err != nil || n <= 0 {
+		return time.Time{}, fmt.Errorf("invalid period %q: use a positive number with d, w, m or y, or all", input)
+	}
+
+	var since time.Time
+	switch unit {
+	case 'd':
+		since = now.AddDate(0, 0, -n)
+	case 'w':
+		since = now.AddDate(0, 0, -7*n)
+	case 'm':
+		since = now.AddDate(0, -n, 0)
+	case 'y':
+		since = now.AddDate(-n, 0, 0)
+	default:
+		return time.Time{}, fmt.Errorf("invalid period unit %q: use d, w, m or y", string(unit))
+	}
+
+	if now.Sub(since) > maxWindowDays*24*time.Hour {
+		return time.Time{}, fmt.Errorf("period too long: use all to analyze the whole mailbox")
+	}
+	return since, nil
+}
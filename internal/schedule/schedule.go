@@ -0,0 +1,135 @@
+// Package schedule times periodic background work such as cloud sync and
+// watch scans. Runs are aligned to the user's local wall clock, spread over
+// the interval so clients don't all hit the backend at once, and runs missed
+// while the machine was asleep are skipped instead of caught up.
+package schedule
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"time"
+)
+
+const (
+	// defaultJitter is the random delay added to each run, as a fraction of
+	// the interval
+	defaultJitter = 0.1
+
+	// pollInterval bounds how long Wait sleeps at once, so a wall clock jump
+	// after resume is noticed quickly
+	pollInterval = 30 * time.Second
+
+	// maxLateness is how late a run may start before it counts as missed
+	maxLateness = 2 * pollInterval
+)
+
+// Schedule decides when periodic work runs
+type Schedule struct {
+	Interval time.Duration
+	Jitter   float64        // Random delay per run as a fraction of Interval
+	Location *time.Location // Time zone slots are aligned to, time.Local when nil
+
+	offset time.Duration // Stable per machine, spreads clients over the interval
+	rand   *rand.Rand
+}
+
+// New returns a schedule running every interval in the local time zone.
+// Each machine gets its own fixed position within the interval.
+func New(interval time.Duration) *Schedule {
+	s := &Schedule{
+		Interval: interval,
+		Jitter:   defaultJitter,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if interval > 0 {
+		s.offset = time.Duration(machineSeed() % uint64(interval)).Truncate(time.Second)
+	}
+	return s
+}
+
+// Next returns the first run after now. Slots start at local midnight and
+// repeat every Interval; intervals of whole days follow the calendar, so a
+// daily run keeps its local time across daylight saving changes.
+func (s *Schedule) Next(now time.Time) time.Time {
+	if s.Interval <= 0 {
+		return now
+	}
+	loc := s.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	local := now.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	var next time.Time
+	if s.Interval%(24*time.Hour) == 0 {
+		days := int(s.Interval / (24 * time.Hour))
+		// Count days from a fixed epoch so the phase doesn't shift daily
+		epoch := time.Date(2000, 1, 1, 0, 0, 0, 0, loc)
+		elapsed := int(midnight.Sub(epoch).Hours()/24+0.5) % days
+		next = midnight.AddDate(0, 0, -elapsed).Add(s.offset)
+		for !next.After(now) {
+			next = next.AddDate(0, 0, days)
+		}
+	} else {
+		// The first slot before midnight, so it is never after now
+		base := midnight.Add(s.offset - s.Interval)
+		next = base.Add(s.Interval * (now.Sub(base)/s.Interval + 1))
+	}
+
+	if s.Jitter > 0 && s.rand != nil {
+		next = next.Add(time.Duration(s.rand.Int63n(int64(float64(s.Interval)*s.Jitter) + 1)))
+	}
+	return next
+}
+
+// Delay returns how long to wait from now until the next run
+func (s *Schedule) Delay(now time.Time) time.Duration {
+	return s.Next(now).Sub(now)
+}
+
+// Missed reports whether a run due at due is starting so late, typically
+// because the machine was asleep, that it should be skipped
+func Missed(due, now time.Time) bool {
+	// Round(0) drops the monotonic reading, which stops during sleep
+	return now.Round(0).Sub(due.Round(0)) > maxLateness
+}
+
+// Wait blocks until the next run is due and returns true, or returns false
+// once stop is closed. After a wake-up it keeps waiting for the next slot
+// instead of running the ones missed.
+func (s *Schedule) Wait(stop <-chan struct{}) bool {
+	next := s.Next(time.Now())
+	for {
+		now := time.Now().Round(0)
+		if !now.Before(next) {
+			if !Missed(next, now) {
+				return true
+			}
+			next = s.Next(now)
+			continue
+		}
+
+		wait := next.Sub(now)
+		if wait > pollInterval {
+			wait = pollInterval
+		}
+		select {
+		case <-stop:
+			return false
+		case <-time.After(wait):
+		}
+	}
+}
+
+// machineSeed is a stable number identifying this machine and user
+func machineSeed() uint64 {
+	h := fnv.New64a()
+	host, _ := os.Hostname()
+	h.Write([]byte(host))
+	if dir, err := os.UserHomeDir(); err == nil {
+		h.Write([]byte(dir))
+	}
+	return h.Sum64()
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strconv"
@@ -15,6 +16,7 @@ import (
 	"github.com/loickal/newsletter-cli/internal/api"
 	"github.com/loickal/newsletter-cli/internal/config"
 	"github.com/loickal/newsletter-cli/internal/imap"
+	"github.com/loickal/newsletter-cli/internal/schedule"
 	"github.com/loickal/newsletter-cli/internal/unsubscribe"
 	"github.com/loickal/newsletter-cli/internal/update"
 )
@@ -33,6 +35,9 @@ const (
 	screenSyncSettings
 	screenDeleteConfirm
 	screenSubscription
+	screenReview
+	screenTeam
+	screenKeepSuggestions
 )
 
 type appModel struct {
@@ -45,6 +50,7 @@ type appModel struct {
 	// Welcome screen
 	welcomeList     list.Model
 	updateAvailable *updateInfo
+	noiseAlert      string // This week's volume when over the noise budget
 	currentVersion  string
 
 	// Login screen
@@ -57,6 +63,8 @@ type appModel struct {
 	// Analyze input screen
 	analyzeInputs  []textinput.Model
 	analyzeFocused int
+	analyzeErr     string // Live validation error for the period input
+	analyzeAll     bool   // Analyze every saved account instead of the current one
 
 	// Analyzing screen
 	analyzingSpinner spinner.Model
@@ -71,6 +79,27 @@ type appModel struct {
 	unsubscribeResults    []unsubscribeResultMsg
 	totalEmails           int
 	totalNewsletters      int
+	dashboardItems        []list.Item           // All items, before the active smart view is applied
+	smartViews            []config.SmartView    // Saved views shown above the dashboard
+	activeView            int                   // 0 is "All", 1..n index into smartViews
+	dashboardFolders      []imap.FolderStat     // Per-folder summary of the last analysis
+	dashboardSince        time.Time             // Start of the analyzed window, zero for the whole mailbox
+	pendingSnapshot       *api.AnalysisSnapshot // Awaiting confirmation before it is shared
+	showFolderHeatmap     bool                  // Show the folder heatmap instead of the list
+	showArrivals          bool                  // Show arrival-time charts instead of the list
+	showHints             bool                  // Show the first-run tips overlay
+	addressFirst          bool                  // Label newsletters by address instead of display name
+	hintStep              int                   // Current step of the tips overlay
+	keepList              []string              // Senders/domains the user chose to keep
+	trustedDomains        []string              // Provider domains derived from configured accounts
+	categoryEdits         map[string]int        // Sender -> edit sequence, to debounce category feedback
+	enrichSeq             int                   // Current analysis, to drop categories of an older one
+	pendingConfirm        string                // "single" or "mass" while a transactional unsubscribe awaits confirmation, "frequency" after opening a preference center, "snapshot" before sharing with the web dashboard
+	pendingSender         string                // Sender whose preference center was opened
+	dashboardAccounts     []accountAnalysis     // Per-account results of a multi-account analysis
+	dashboardAccount      int                   // 0 is the aggregate, 1..n index into dashboardAccounts
+	accountSelections     map[int]map[string]bool
+	accountItems          map[int][]list.Item // Categorized dashboard items by dashboardAccount, reused when cycling back
 
 	// Saved credentials (for skipping login)
 	savedEmail    string
@@ -94,12 +123,14 @@ type appModel struct {
 	premiumAPIURL   string
 	premiumTier     string
 	premiumFeatures []string
+	premiumPage     int // Shown page of the premium screen in compact mode
 
 	// Quit confirmation
 	quitConfirmSyncing bool
 
 	// Sync status
 	syncStatusMsg      string
+	sessionExpired     bool // Premium refresh token was rejected, re-login needed
 	isSyncing          bool
 	lastSyncStatusTime time.Time
 
@@ -112,6 +143,27 @@ type appModel struct {
 	subscriptionMsg     string
 	subscriptionLoading bool
 	currentSubscription *api.Subscription
+
+	// Review screen (newsletters found by the watch daemon)
+	reviewList list.Model
+	reviewMsg  string
+
+	// Keep suggestions screen (senders the user engages with)
+	keepSuggestionList list.Model
+	keepSuggestionMsg  string
+
+	// Command palette (Ctrl+P), shown over any screen
+	showPalette  bool
+	paletteInput textinput.Model
+	paletteIndex int
+
+	// Team seats screen (enterprise)
+	teamList          list.Model
+	teamSeats         *api.TeamSeats
+	teamInviteInput   textinput.Model
+	teamInviting      bool
+	teamPendingRemove *api.TeamSeat // Seat awaiting removal confirmation
+	teamMsg           string
 }
 
 type updateInfo struct {
@@ -153,7 +205,7 @@ func NewAppModel(savedEmail, savedPassword, savedServer string, currentVersion s
 	daysInput := textinput.New()
 	daysInput.Placeholder = "30"
 	daysInput.Focus()
-	daysInput.CharLimit = 3
+	daysInput.CharLimit = 5
 	daysInput.Width = 10
 
 	// Initialize premium inputs
@@ -194,6 +246,19 @@ func NewAppModel(savedEmail, savedPassword, savedServer string, currentVersion s
 			description: "Analyze and manage newsletters",
 			action:      screenAnalyzeInput,
 		})
+
+		// Show newsletters found by the watch daemon since the last session
+		if count := pendingReviewCount(savedEmail); count > 0 {
+			items = append(items, appMenuItem{
+				title:       fmt.Sprintf("📥 Review (%d)", count),
+				description: "New newsletters found while you were away",
+				action:      screenReview,
+			})
+		}
+
+		if count := pendingKeepSuggestionCount(savedEmail); count > 0 {
+			items = append(items, keepSuggestionsMenuItem(count))
+		}
 	}
 
 	// Always show Accounts option
@@ -225,28 +290,13 @@ func NewAppModel(savedEmail, savedPassword, savedServer string, currentVersion s
 		action:      screenWelcome, // Will quit anyway
 	})
 
-	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("229")).
-		Bold(true)
-	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("219"))
-
-	welcomeList := list.New(items, delegate, 0, 0)
 	// Check if premium is enabled for title
 	premiumConfig, _ := api.GetPremiumConfig()
 	premiumBadge := ""
 	if premiumConfig != nil && premiumConfig.Enabled {
 		premiumBadge = " ☁️"
 	}
-	welcomeList.Title = "📬  Newsletter CLI" + premiumBadge
-	welcomeList.SetShowStatusBar(false)
-	welcomeList.SetFilteringEnabled(false)
-	welcomeList.Styles.Title = lipgloss.NewStyle().
-		Background(lipgloss.Color("63")).
-		Foreground(lipgloss.Color("230")).
-		Bold(true).
-		Padding(0, 1)
+	welcomeList := newList(items, "📬  Newsletter CLI"+premiumBadge, false)
 
 	// Pre-fill inputs if credentials exist
 	if savedEmail != "" {
@@ -261,7 +311,7 @@ func NewAppModel(savedEmail, savedPassword, savedServer string, currentVersion s
 
 	// Check if premium is enabled
 	pc, _ := api.GetPremiumConfig()
-	premiumEnabled := pc != nil && pc.Enabled
+	premiumEnabled := pc != nil && pc.Enabled && !pc.SessionExpired
 
 	// Pre-fill premium inputs if configured
 	if pc != nil {
@@ -275,6 +325,7 @@ func NewAppModel(savedEmail, savedPassword, savedServer string, currentVersion s
 
 	return appModel{
 		screen:                screenWelcome,
+		noiseAlert:            noiseAlert(),
 		welcomeList:           welcomeList,
 		loginInputs:           []textinput.Model{emailInput, passwordInput, serverInput},
 		loginFocused:          0,
@@ -289,6 +340,7 @@ func NewAppModel(savedEmail, savedPassword, savedServer string, currentVersion s
 		premiumInputs:         []textinput.Model{apiURLInput, premiumEmailInput, premiumPasswordInput},
 		premiumFocused:        0,
 		premiumEnabled:        premiumEnabled,
+		sessionExpired:        pc != nil && pc.Enabled && pc.SessionExpired,
 		premiumAPIURL: func() string {
 			if premiumConfig != nil {
 				return premiumConfig.APIURL
@@ -335,10 +387,6 @@ func (m appModel) Init() tea.Cmd {
 			}
 		}
 
-		if autoSyncOnStartup {
-			cmds = append(cmds, m.checkAndSyncOnStartup())
-		}
-
 		// Start periodic sync ticker if enabled
 		periodicSyncEnabled := true
 		periodicInterval := 5 * time.Minute
@@ -359,15 +407,18 @@ func (m appModel) Init() tea.Cmd {
 		}
 
 		if periodicSyncEnabled {
-			cmds = append(cmds, tea.Tick(periodicInterval, func(t time.Time) tea.Msg {
-				return periodicSyncTick{}
-			}))
+			cmds = append(cmds, schedulePeriodicSync(schedule.New(periodicInterval)))
 		}
 
-		// Fetch subscription status on startup if premium enabled
-		if pc != nil && pc.Enabled && pc.Token != "" {
-			// Will be triggered when premium screen is viewed
+		// License and subscription load alongside the auto-sync, so the UI
+		// shows right away and premium details fill in as they arrive
+		details := pc != nil && pc.Enabled && pc.Token != ""
+		if cmd := m.loadPremiumState(autoSyncOnStartup, details); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
+	} else if license, _ := api.LoadOfflineLicense(time.Now()); license != nil {
+		// An offline license unlocks features without a premium login
+		cmds = append(cmds, m.fetchLicenseFeatures(context.Background()))
 	}
 
 	return tea.Batch(cmds...)
@@ -407,7 +458,20 @@ type unsubscribeResultMsg struct {
 	results []unsubscribe.UnsubscribeResult
 }
 
-type periodicSyncTick struct{}
+// periodicSyncTick is sent when a periodic sync is due
+type periodicSyncTick struct {
+	due      time.Time
+	schedule *schedule.Schedule
+}
+
+// schedulePeriodicSync waits for the next slot of the sync schedule
+func schedulePeriodicSync(s *schedule.Schedule) tea.Cmd {
+	now := time.Now()
+	due := s.Next(now)
+	return tea.Tick(due.Sub(now), func(time.Time) tea.Msg {
+		return periodicSyncTick{due: due, schedule: s}
+	})
+}
 
 type autoSyncCompleteMsg struct {
 	synced bool
@@ -422,9 +486,9 @@ type manualSyncCompleteMsg struct {
 	err error
 }
 
-func (m appModel) checkAndSyncOnStartup() tea.Cmd {
+func (m appModel) checkAndSyncOnStartup(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
-		synced, err := api.CheckAndSyncIfNeeded()
+		synced, err := api.CheckAndSyncIfNeeded(ctx)
 		return autoSyncCompleteMsg{synced: synced, err: err}
 	}
 }
@@ -432,6 +496,9 @@ func (m appModel) checkAndSyncOnStartup() tea.Cmd {
 func (m appModel) periodicSync() tea.Cmd {
 	return func() tea.Msg {
 		err := api.PeriodicSync()
+		if api.IsSessionExpired() {
+			return sessionExpiredMsg{}
+		}
 		if err != nil {
 			// Silently log but don't show error to user
 			return nil
@@ -458,10 +525,30 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Handle special messages first
 	switch msg := msg.(type) {
 	case periodicSyncTick:
-		// Periodic sync tick - push local changes to cloud
-		return m, m.periodicSync()
+		// Periodic sync tick - push local changes to cloud, unless the tick
+		// only fires now because the machine was asleep
+		next := schedulePeriodicSync(msg.schedule)
+		if schedule.Missed(msg.due, time.Now()) {
+			return m, next
+		}
+		return m, tea.Batch(m.periodicSync(), next)
+	case sessionExpiredMsg:
+		m.checkSession()
+		return m, nil
+	case licenseFeaturesMsg:
+		// Handled on every screen, as the startup fetch may finish anywhere
+		if msg.err == nil {
+			m.premiumTier = msg.tier
+			m.premiumFeatures = msg.features
+		}
+		return m, nil
+	case subscriptionStatusMsg:
+		// Errors are ignored, the user might not have a subscription yet
+		m.currentSubscription = msg.subscription
+		return m, nil
 	case autoSyncCompleteMsg:
 		// Auto-sync completed on startup - silently handle
+		m.checkSession()
 		if msg.synced {
 			m.lastSyncStatusTime = time.Now()
 			m.syncStatusMsg = "✅ Synced"
@@ -475,6 +562,10 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case manualSyncCompleteMsg:
 		// Manual sync completed
 		m.isSyncing = false
+		m.checkSession()
+		if m.sessionExpired {
+			return m, nil
+		}
 		if msg.err != nil {
 			m.syncStatusMsg = "❌ Sync failed: " + msg.err.Error()
 		} else {
@@ -490,14 +581,29 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		applyLayout(m.compact())
 		h, v := docStyle.GetFrameSize()
-		m.welcomeList.SetSize(msg.Width-h, msg.Height-v-6)
+		welcomeChrome := 6
+		if m.compact() {
+			welcomeChrome = 2 // No intro
+		}
+		m.welcomeList.SetSize(msg.Width-h, msg.Height-v-welcomeChrome)
 		if m.dashboardList.Width() > 0 {
-			m.dashboardList.SetSize(msg.Width-h, msg.Height-v-7)
+			m.dashboardList.SetSize(msg.Width-h, msg.Height-v-m.listChrome())
+		}
+		if m.reviewList.Width() > 0 {
+			m.reviewList.SetSize(msg.Width-h, msg.Height-v-m.listChrome()+2)
+		}
+		if m.keepSuggestionList.Width() > 0 {
+			m.keepSuggestionList.SetSize(msg.Width-h, msg.Height-v-m.listChrome()+2)
+		}
+		if m.teamList.Width() > 0 {
+			m.teamList.SetSize(msg.Width-h, msg.Height-v-m.listChrome())
 		}
 		return m, nil
 
 	case loginSuccessMsg:
+		logAction("Logged in to %s (%s)", msg.email, msg.server)
 		m.savedEmail = msg.email
 		m.savedPassword = msg.password
 		m.savedServer = msg.server
@@ -533,145 +639,47 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			},
 		}
 
-		// Create new list with updated items
-		delegate := list.NewDefaultDelegate()
-		delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-			Foreground(lipgloss.Color("229")).
-			Bold(true)
-		delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-			Foreground(lipgloss.Color("219"))
-
 		m.welcomeList.SetItems(items)
 
 		return m, nil
 
 	case analysisCompleteMsg:
-		// Sort stats
-		sort.Slice(msg.stats, func(i, j int) bool {
-			return msg.stats[i].Count > msg.stats[j].Count
-		})
-
-		// Load unsubscribed list
-		unsubscribedList, _ := config.GetUnsubscribedList()
-		m.dashboardUnsubscribed = unsubscribedList
-
-		// Send analytics events (async, non-blocking)
-		go func() {
-			// Convert stats to analytics format
-			analyticsStats := make([]api.NewsletterStatForAnalytics, 0, len(msg.stats))
-			for _, s := range msg.stats {
-				analyticsStats = append(analyticsStats, api.ConvertNewsletterStatsToAnalytics(
-					s.Sender,
-					s.Count,
-					s.Unsubscribe,
-				))
-			}
-			// Send analytics (silently fail if premium not enabled)
-			_ = api.SendNewsletterAnalysisEvent(analyticsStats, m.savedEmail)
-		}()
-
-		// Create dashboard
-		items := []list.Item{}
-		totalEmails := 0
-
-		// Check if premium is enabled AND user has active subscription (for categorization and quality scoring)
-		premiumConfig, _ := api.GetPremiumConfig()
-		hasPremiumConfig := premiumConfig != nil && premiumConfig.Enabled
-
-		// Check if user has active subscription by checking license features
-		isPremium := false
-		if hasPremiumConfig {
-			// Check subscription status by fetching features (which validates active subscription)
-			features, err := api.GetLicenseFeatures()
-			if err == nil {
-				if tier, ok := features["tier"].(string); ok && tier != "" && tier != "free" {
-					isPremium = true
-				}
-			}
+		// Send analytics events (async, non-blocking), one per analyzed account
+		analyzed := msg.accounts
+		if len(analyzed) == 0 {
+			analyzed = []accountAnalysis{{email: m.savedEmail, stats: msg.stats}}
 		}
-
-		// Prepare enrichment inputs for API call
-		enrichInputs := make([]api.EnrichNewsletterInput, 0, len(msg.stats))
-		for _, s := range msg.stats {
-			enrichInputs = append(enrichInputs, api.EnrichNewsletterInput{
-				Sender:         s.Sender,
-				EmailCount:     s.Count,
-				HasUnsubscribe: s.Unsubscribe != "",
-			})
-		}
-
-		// Enrich newsletters using API (with caching)
-		enrichedNewsletters := make(map[string]api.EnrichNewsletter)
-		if isPremium && len(enrichInputs) > 0 {
-			// Try to enrich via API (with caching)
-			enriched, err := api.EnrichNewslettersWithCache(enrichInputs)
-			if err == nil {
-				for _, e := range enriched {
-					enrichedNewsletters[e.Sender] = e
+		go func() {
+			for _, a := range analyzed {
+				// Convert stats to analytics format
+				analyticsStats := make([]api.NewsletterStatForAnalytics, 0, len(a.stats))
+				for _, s := range a.stats {
+					analyticsStats = append(analyticsStats, api.ConvertNewsletterStatsToAnalytics(
+						s.Sender.Key(),
+						s.Count,
+						s.Unsubscribe,
+					))
 				}
+				// Send analytics (silently fail if premium not enabled)
+				_ = api.SendNewsletterAnalysisEvent(analyticsStats, a.email)
 			}
-			// If API fails, silently fall back to showing without categories/scores
-		}
-
-		for _, s := range msg.stats {
-			var category string
-			var qualityScore int
-
-			// Use enriched data if available
-			if enriched, found := enrichedNewsletters[s.Sender]; found && isPremium {
-				category = enriched.Category.Category
-				qualityScore = enriched.QualityScore
-			}
-
-			items = append(items, dashboardListItem{
-				title:        s.Sender,
-				count:        s.Count,
-				link:         s.Unsubscribe,
-				selected:     m.dashboardSelected[s.Sender], // Preserve selection state
-				unsubscribed: m.dashboardUnsubscribed[s.Sender],
-				category:     category,
-				qualityScore: qualityScore,
-				isPremium:    isPremium,
-			})
-			totalEmails += s.Count
-		}
-
-		delegate := list.NewDefaultDelegate()
-		delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-			Foreground(lipgloss.Color("229")).
-			Bold(true)
-		delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-			Foreground(lipgloss.Color("219"))
-
-		l := list.New(items, delegate, 0, 0)
-		l.Title = "📬  Newsletter Overview"
-		l.SetShowStatusBar(false)
-		l.SetFilteringEnabled(true)
-		l.Styles.Title = lipgloss.NewStyle().
-			Background(lipgloss.Color("63")).
-			Foreground(lipgloss.Color("230")).
-			Bold(true).
-			Padding(0, 1)
-
-		h, v := docStyle.GetFrameSize()
-		if m.width > 0 && m.height > 0 {
-			l.SetSize(m.width-h, m.height-v-7)
-		}
+		}()
 
-		m.dashboardList = l
-		m.dashboardStats = msg.stats
+		m.dashboardAccounts = msg.accounts
+		m.dashboardAccount = 0
+		m.accountSelections = nil
+		m.accountItems = nil
 		m.dashboardSelected = make(map[string]bool)
-		// dashboardUnsubscribed already loaded above
-		if m.dashboardUnsubscribed == nil {
-			m.dashboardUnsubscribed = make(map[string]bool)
+		m.dashboardSince = msg.since
+		m.refreshKeepSuggestionsMenuItem()
+		cmd := m.buildDashboard(msg.stats, msg.folders)
+
+		// Walk first-time users through the dashboard
+		if settings, err := config.LoadSettings(); err == nil {
+			m.showHints = !settings.HintsSeen && len(msg.stats) > 0
+			m.hintStep = 0
 		}
-		m.unsubscribing = false
-		m.unsubscribeResults = nil
-		m.totalEmails = totalEmails
-		m.totalNewsletters = len(msg.stats)
-		m.screen = screenDashboard
-		m.errMsg = ""
-		return m, nil
+		return m, cmd
 
 	case errorMsg:
 		m.errMsg = string(msg)
@@ -693,6 +701,21 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateAvailable = msg.update
 		return m, nil
 
+	case categoryFeedbackMsg:
+		return m, m.sendCategoryFeedback(msg)
+
+	case enrichmentMsg:
+		return m.handleEnrichment(msg)
+
+	case snapshotUploadedMsg:
+		if msg.err != nil {
+			m.dashboardMsg = "❌  Failed to share snapshot: " + msg.err.Error()
+			return m, nil
+		}
+		logAction("Shared an analysis snapshot with the web dashboard")
+		m.dashboardMsg = "☁️  Snapshot shared with the web dashboard"
+		return m, nil
+
 	case serverDiscoveredMsg:
 		m.discoveringServer = false
 		if msg.err != nil {
@@ -707,6 +730,23 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Command palette, from any screen
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		if m.showPalette {
+			return m.updatePalette(keyMsg)
+		}
+		if keyMsg.String() == "ctrl+p" {
+			return m.openPalette()
+		}
+	}
+
+	// Re-login prompt after the premium session expired
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.sessionExpired && keyMsg.String() == "L" {
+		if m.screen == screenWelcome || (m.screen == screenDashboard && m.dashboardList.FilterState() != list.Filtering) {
+			return m.openPremiumRelogin()
+		}
+	}
+
 	// Handle global shortcuts (before screen-specific handlers)
 	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.premiumEnabled {
 		switch keyMsg.String() {
@@ -745,6 +785,12 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateDeleteConfirm(msg)
 	case screenSubscription:
 		return m.updateSubscription(msg)
+	case screenReview:
+		return m.updateReview(msg)
+	case screenKeepSuggestions:
+		return m.updateKeepSuggestions(msg)
+	case screenTeam:
+		return m.updateTeam(msg)
 	}
 
 	return m, nil
@@ -772,50 +818,7 @@ func (m appModel) updateWelcome(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					return m, tea.Quit // Quit option
 				}
-				if i.action == screenAccounts {
-					// Load accounts and initialize accounts screen
-					accounts, err := config.GetAllAccounts()
-					if err != nil {
-						m.errMsg = "Failed to load accounts: " + err.Error()
-						return m, nil
-					}
-					m.accounts = accounts
-					m.screen = screenAccounts
-					// Initialize accounts list
-					return m.initAccountsList()
-				}
-				if i.action == screenPremium {
-					m.screen = screenPremium
-					m.premiumInputs[0].Focus()
-					for i := 1; i < len(m.premiumInputs); i++ {
-						m.premiumInputs[i].Blur()
-					}
-					m.premiumFocused = 0
-					// Fetch license features and subscription status asynchronously if premium is enabled
-					if m.premiumEnabled {
-						return m, tea.Batch(m.fetchLicenseFeatures(), m.fetchSubscriptionStatus())
-					}
-					return m, nil
-				}
-				m.screen = i.action
-				switch m.screen {
-				case screenLogin:
-					m.loginInputs[0].Focus()
-					for i := 1; i < len(m.loginInputs); i++ {
-						m.loginInputs[i].Blur()
-					}
-					// Try to discover server if email is already filled
-					email := strings.TrimSpace(m.loginInputs[0].Value())
-					if email != "" {
-						m.discoveringServer = true
-						m.serverStatusMsg = "🔍 Discovering IMAP server..."
-						return m, m.discoverServer(email)
-					}
-				case screenAnalyzeInput:
-					// Always show the input screen to let user specify days
-					m.analyzeInputs[0].Focus()
-				}
-				return m, nil
+				return m.openScreen(i.action)
 			}
 		}
 	}
@@ -825,6 +828,62 @@ func (m appModel) updateWelcome(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// openScreen navigates to a screen from the welcome menu or the command
+// palette, preparing it the way the screen expects
+func (m appModel) openScreen(action screen) (tea.Model, tea.Cmd) {
+	if action == screenAccounts {
+		// Load accounts and initialize accounts screen
+		accounts, err := config.GetAllAccounts()
+		if err != nil {
+			m.errMsg = "Failed to load accounts: " + err.Error()
+			return m, nil
+		}
+		m.accounts = accounts
+		m.screen = screenAccounts
+		// Initialize accounts list
+		return m.initAccountsList()
+	}
+	if action == screenReview {
+		return m.initReviewList()
+	}
+	if action == screenKeepSuggestions {
+		return m.initKeepSuggestions()
+	}
+	if action == screenPremium {
+		m.screen = screenPremium
+		m.premiumPage = 0
+		m.premiumInputs[0].Focus()
+		for i := 1; i < len(m.premiumInputs); i++ {
+			m.premiumInputs[i].Blur()
+		}
+		m.premiumFocused = 0
+		// Fetch license features and subscription status asynchronously if premium is enabled
+		if m.premiumEnabled {
+			return m, tea.Batch(m.fetchLicenseFeatures(context.Background()), m.fetchSubscriptionStatus(context.Background()))
+		}
+		return m, nil
+	}
+	m.screen = action
+	switch m.screen {
+	case screenLogin:
+		m.loginInputs[0].Focus()
+		for i := 1; i < len(m.loginInputs); i++ {
+			m.loginInputs[i].Blur()
+		}
+		// Try to discover server if email is already filled
+		email := strings.TrimSpace(m.loginInputs[0].Value())
+		if email != "" {
+			m.discoveringServer = true
+			m.serverStatusMsg = "🔍 Discovering IMAP server..."
+			return m, m.discoverServer(email)
+		}
+	case screenAnalyzeInput:
+		// Always show the input screen to let user specify days
+		m.analyzeInputs[0].Focus()
+	}
+	return m, nil
+}
+
 func (m appModel) updateLogin(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case spinner.TickMsg:
@@ -948,7 +1007,15 @@ func (m appModel) updateAnalyzeInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "esc", "ctrl+c":
 			m.screen = screenWelcome
 			return m, nil
+		case "tab":
+			if accounts, err := config.GetAllAccounts(); err == nil && len(accounts) > 1 {
+				m.analyzeAll = !m.analyzeAll
+			}
+			return m, nil
 		case "enter":
+			if m.analyzeErr != "" {
+				return m, nil
+			}
 			// Start analysis
 			m.screen = screenAnalyzing
 			return m, m.startAnalysis()
@@ -957,6 +1024,14 @@ func (m appModel) updateAnalyzeInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	m.analyzeInputs[m.analyzeFocused], cmd = m.analyzeInputs[m.analyzeFocused].Update(msg)
+
+	// Validate as the user types; an empty input falls back to the default
+	m.analyzeErr = ""
+	if value := strings.TrimSpace(m.analyzeInputs[0].Value()); value != "" {
+		if _, err := imap.ParseSearchWindow(value); err != nil {
+			m.analyzeErr = err.Error()
+		}
+	}
 	return m, cmd
 }
 
@@ -981,29 +1056,36 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.unsubscribeResults = []unsubscribeResultMsg{msg}
 
 		// Build result summary
+		m.dashboardMsg = ""
 		successCount := 0
 		failCount := 0
 		for _, result := range msg.results {
+			if result.Paused {
+				// Stays selected so it can be retried once the provider is back
+				continue
+			}
 			if result.Success {
+				logAction("Unsubscribed from %s (%s)", result.Sender, result.Method)
 				successCount++
 				// Remove from selected after successful unsubscribe
 				delete(m.dashboardSelected, result.Sender)
 				// Save to unsubscribed list
 				m.dashboardUnsubscribed[result.Sender] = true
-				config.AddUnsubscribed(result.Sender)
+				config.AddUnsubscribed(config.NewSenderIdentity("", result.Sender))
 				// Send analytics event (async, non-blocking)
 				go func(sender string) {
-					_ = api.SendUnsubscribeEvent(sender, true, m.savedEmail)
+					_ = api.SendUnsubscribeEvent(sender, true, m.dashboardEmail())
 				}(result.Sender)
 				// Auto-sync to cloud if premium enabled
 				go func() {
 					_ = api.AutoSync() // Silently fail if premium not enabled
 				}()
 			} else {
+				logAction("Failed to unsubscribe from %s: %s", result.Sender, result.ErrorMsg)
 				failCount++
 				// Send analytics event for failed unsubscribe
 				go func(sender string) {
-					_ = api.SendUnsubscribeEvent(sender, false, m.savedEmail)
+					_ = api.SendUnsubscribeEvent(sender, false, m.dashboardEmail())
 				}(result.Sender)
 			}
 		}
@@ -1014,6 +1096,16 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if failCount > 0 {
 			m.dashboardMsg += fmt.Sprintf(" | ❌ Failed: %d", failCount)
 		}
+		outages := unsubscribe.Outages(msg.results)
+		providers := make([]string, 0, len(outages))
+		for provider := range outages {
+			providers = append(providers, provider)
+		}
+		sort.Strings(providers)
+		for _, provider := range providers {
+			m.dashboardMsg += fmt.Sprintf(" | ⚠️  %s endpoints appear down — %d paused, retry later", provider, outages[provider])
+		}
+		m.dashboardMsg = strings.TrimPrefix(m.dashboardMsg, " | ")
 
 		// Update list items to reflect unsubscribed status
 		items := m.dashboardList.Items()
@@ -1028,6 +1120,32 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if msg, ok := msg.(tea.KeyMsg); ok && m.showHints {
+		return m.updateHints(msg)
+	}
+
+	// Extra confirmation before unsubscribing from transactional senders
+	if msg, ok := msg.(tea.KeyMsg); ok && m.pendingConfirm != "" {
+		action := m.pendingConfirm
+		m.pendingConfirm = ""
+		if action == "frequency" {
+			return m.confirmFrequencyReduced(msg.String() == "y" || msg.String() == "Y")
+		}
+		if action == "snapshot" {
+			return m.confirmSnapshotUpload(msg.String() == "y" || msg.String() == "Y")
+		}
+		if msg.String() != "y" && msg.String() != "Y" {
+			m.dashboardMsg = "Cancelled"
+			return m, nil
+		}
+		if action == "single" {
+			return m.openUnsubscribeLink()
+		}
+		m.unsubscribing = true
+		m.dashboardMsg = fmt.Sprintf("🔄 Unsubscribing from %d newsletter(s)...", len(m.dashboardSelected))
+		return m, m.batchUnsubscribe()
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -1037,7 +1155,15 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.unsubscribing {
 				return m, nil // Don't allow selection while unsubscribing
 			}
+			if m.inAggregateView() {
+				m.dashboardMsg = "👥 Switch to an account with [a] to select newsletters"
+				return m, nil
+			}
 			i, ok := m.dashboardList.SelectedItem().(dashboardListItem)
+			if ok && i.kept {
+				m.dashboardMsg = "🛡️  " + i.title + " is on your keep list. Press [k] to remove it first."
+				return m, nil
+			}
 			if ok {
 				// Toggle selection
 				if m.dashboardSelected[i.title] {
@@ -1059,18 +1185,20 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "u":
 			// Single unsubscribe (open browser)
 			i, ok := m.dashboardList.SelectedItem().(dashboardListItem)
-			if ok {
-				if i.link == "" {
-					m.dashboardMsg = "❌  No unsubscribe link found for " + i.title
+			if ok && i.kept {
+				m.dashboardMsg = "🛡️  " + i.title + " is on your keep list. Press [k] to remove it first."
+				return m, nil
+			}
+			if ok && i.link != "" && config.ShouldConfirm(i.transactional) {
+				m.pendingConfirm = "single"
+				if i.transactional {
+					m.dashboardMsg = "⚠️  " + i.title + " looks transactional (receipts, password resets, security alerts). Unsubscribe anyway? [y/N]"
 				} else {
-					if err := openBrowser(i.link); err != nil {
-						m.dashboardMsg = "❌  Failed to open browser: " + err.Error() + " | Link: " + i.link
-					} else {
-						m.dashboardMsg = "🔗  Opening: " + i.link
-					}
+					m.dashboardMsg = "Unsubscribe from " + i.title + "? [y/N]"
 				}
+				return m, nil
 			}
-			return m, nil
+			return m.openUnsubscribeLink()
 		case "U": // Shift+U or uppercase U for mass unsubscribe
 			selectedCount := len(m.dashboardSelected)
 			if selectedCount == 0 {
@@ -1082,6 +1210,24 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+			// Ask again if any selected sender looks transactional
+			var transactional []string
+			for _, stat := range m.dashboardStats {
+				if m.dashboardSelected[stat.Sender.Key()] && stat.Transactional {
+					transactional = append(transactional, stat.Sender.Key())
+				}
+			}
+			if config.ShouldConfirm(len(transactional) > 0) {
+				m.pendingConfirm = "mass"
+				if len(transactional) > 0 {
+					m.dashboardMsg = fmt.Sprintf("⚠️  %d selected sender(s) look transactional: %s. Unsubscribe anyway? [y/N]",
+						len(transactional), strings.Join(transactional, ", "))
+				} else {
+					m.dashboardMsg = fmt.Sprintf("Unsubscribe from %d newsletter(s)? [y/N]", selectedCount)
+				}
+				return m, nil
+			}
+
 			// Start mass unsubscribe
 			m.unsubscribing = true
 			m.dashboardMsg = fmt.Sprintf("🔄 Unsubscribing from %d newsletter(s)...", selectedCount)
@@ -1089,6 +1235,108 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "/":
 			m.dashboardList.ResetSelected()
 			return m, nil
+		case "k":
+			// Toggle the highlighted sender on the keep list
+			if m.dashboardList.FilterState() == list.Filtering {
+				break
+			}
+			i, ok := m.dashboardList.SelectedItem().(dashboardListItem)
+			if !ok {
+				return m, nil
+			}
+			if config.IsKept(i.sender, nil, m.trustedDomains) {
+				m.dashboardMsg = "🛡️  " + i.title + " is from one of your email providers and is always kept"
+				return m, nil
+			}
+			kept, err := config.ToggleKeep(i.title)
+			if err != nil {
+				m.dashboardMsg = "❌  Failed to update keep list: " + err.Error()
+				return m, nil
+			}
+			if settings, err := config.LoadSettings(); err == nil {
+				m.keepList = settings.KeepList
+			}
+			m.refreshKeptItems()
+			if kept {
+				logAction("Added %s to the keep list", i.title)
+				m.dashboardMsg = "🛡️  Keeping " + i.title
+			} else {
+				logAction("Removed %s from the keep list", i.title)
+				m.dashboardMsg = "Removed " + i.title + " from keep list"
+			}
+			return m, nil
+		case "c", "C":
+			// Reassign the category; C resets to the predicted one
+			if m.dashboardList.FilterState() == list.Filtering {
+				break
+			}
+			i, ok := m.dashboardList.SelectedItem().(dashboardListItem)
+			if !ok || !i.categorized {
+				m.dashboardMsg = "📂 Not categorized, choose a categorizer with `newsletter-cli config categorizer`"
+				return m, nil
+			}
+			return m.cycleCategory(i, msg.String() == "C")
+		case "f":
+			// Toggle the per-folder heatmap
+			if m.dashboardList.FilterState() == list.Filtering {
+				break
+			}
+			m.showFolderHeatmap = !m.showFolderHeatmap
+			m.showArrivals = false
+			return m, nil
+		case "t":
+			// Toggle arrival-time charts; arrows still move through the list
+			if m.dashboardList.FilterState() == list.Filtering {
+				break
+			}
+			m.showArrivals = !m.showArrivals
+			m.showFolderHeatmap = false
+			return m, nil
+		case "a":
+			// Cycle between the accounts of a multi-account analysis
+			if m.dashboardList.FilterState() == list.Filtering || len(m.dashboardAccounts) == 0 || m.unsubscribing {
+				break
+			}
+			return m.cycleDashboardAccount()
+		case "p":
+			// Open the preference center to reduce frequency instead
+			if m.dashboardList.FilterState() == list.Filtering {
+				break
+			}
+			return m.openPreferenceCenter()
+		case "S":
+			// Share a summary of the analysis with the web dashboard
+			if m.dashboardList.FilterState() == list.Filtering || m.unsubscribing {
+				break
+			}
+			return m.shareSnapshot()
+		case "n":
+			// Switch between display names and addresses
+			if m.dashboardList.FilterState() == list.Filtering {
+				break
+			}
+			m.toggleSenderDisplay()
+			return m, nil
+		case "?":
+			// Replay the first-run tips
+			if m.dashboardList.FilterState() == list.Filtering {
+				break
+			}
+			m.showHints = true
+			m.hintStep = 0
+			return m, nil
+		case "tab", "shift+tab":
+			// Cycle through smart views (not while typing a search)
+			if m.dashboardList.FilterState() == list.Filtering || len(m.smartViews) == 0 {
+				break
+			}
+			if msg.String() == "tab" {
+				m.activeView = (m.activeView + 1) % (len(m.smartViews) + 1)
+			} else {
+				m.activeView = (m.activeView + len(m.smartViews)) % (len(m.smartViews) + 1)
+			}
+			m.applySmartView()
+			return m, nil
 		case "esc":
 			if m.dashboardList.FilterState() == list.Filtering {
 				m.dashboardList.ResetFilter()
@@ -1106,6 +1354,129 @@ func (m appModel) updateDashboard(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// buildDashboard fills the dashboard with the given analysis results, keeping
+// the current selection, and starts categorizing them
+func (m *appModel) buildDashboard(stats []imap.NewsletterStat, folders []imap.FolderStat) tea.Cmd {
+	// Sort stats
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Count > stats[j].Count
+	})
+
+	// Load unsubscribed list
+	unsubscribedList, _ := config.GetUnsubscribedList()
+	m.dashboardUnsubscribed = unsubscribedList
+
+	// Create dashboard
+	items := []list.Item{}
+	totalEmails := 0
+
+	// Never offer the user's own providers for unsubscribe
+	if accounts, err := config.GetAllAccounts(); err == nil {
+		m.trustedDomains = config.TrustedDomains(accounts)
+	}
+
+	// Categories the user assigned by hand win over enrichment
+	var categoryOverrides map[string]string
+	settings, err := config.LoadSettings()
+	if err == nil {
+		categoryOverrides = settings.CategoryOverrides
+		m.addressFirst = settings.AddressFirst
+	}
+	frequencyReduced, err := config.LoadFrequencyStore()
+	if err != nil {
+		frequencyReduced = &config.FrequencyStore{}
+	}
+
+	// Prepare enrichment inputs, categorized in the background
+	enrichInputs := make([]api.EnrichNewsletterInput, 0, len(stats))
+	for _, s := range stats {
+		enrichInputs = append(enrichInputs, api.EnrichNewsletterInput{
+			Sender:         s.Sender.Key(),
+			EmailCount:     s.Count,
+			HasUnsubscribe: s.Unsubscribe != "",
+			TrackingHeavy:  s.TrackingHeavy(),
+		})
+	}
+
+	for _, s := range stats {
+		items = append(items, dashboardListItem{
+			title:         s.Sender.Key(),
+			sender:        s.Sender,
+			addressFirst:  m.addressFirst,
+			count:         s.Count,
+			link:          s.Unsubscribe,
+			preferences:   s.Preferences,
+			selected:      m.dashboardSelected[s.Sender.Key()], // Preserve selection state
+			unsubscribed:  m.dashboardUnsubscribed[s.Sender.Key()],
+			transactional: s.Transactional,
+			trackingHeavy: s.TrackingHeavy(),
+			categorizing:  len(enrichInputs) > 0,
+
+			frequencyReduced: frequencyReduced.IsFrequencyReduced(s.Sender.Key()),
+		})
+		totalEmails += s.Count
+	}
+
+	l := newList(items, "📬  Newsletter Overview", true)
+
+	h, v := docStyle.GetFrameSize()
+	if m.width > 0 && m.height > 0 {
+		l.SetSize(m.width-h, m.height-v-m.listChrome())
+	}
+
+	m.dashboardList = l
+	m.dashboardItems = items
+	if settings, err := config.LoadSettings(); err == nil {
+		m.smartViews = settings.SmartViews
+		m.keepList = settings.KeepList
+	}
+	if m.activeView > len(m.smartViews) {
+		m.activeView = 0
+	}
+	m.applySmartView()
+	m.dashboardStats = stats
+	m.dashboardFolders = folders
+	// dashboardUnsubscribed already loaded above
+	if m.dashboardUnsubscribed == nil {
+		m.dashboardUnsubscribed = make(map[string]bool)
+	}
+	m.refreshKeptItems()
+	m.unsubscribing = false
+	m.unsubscribeResults = nil
+	m.totalEmails = totalEmails
+	m.totalNewsletters = len(stats)
+	m.screen = screenDashboard
+	m.errMsg = ""
+
+	// Categories are filled in as the categorizer gets through them
+	m.enrichSeq++
+	if len(enrichInputs) == 0 {
+		return nil
+	}
+	return startEnrichment(m.enrichSeq, enrichInputs, settings, categoryOverrides, m.premiumTier)
+}
+
+// openUnsubscribeLink opens the unsubscribe link of the highlighted newsletter
+func (m appModel) openUnsubscribeLink() (tea.Model, tea.Cmd) {
+	i, ok := m.dashboardList.SelectedItem().(dashboardListItem)
+	if ok {
+		if i.link == "" {
+			m.dashboardMsg = "❌  No unsubscribe link found for " + i.title
+		} else if err := config.CheckWritable(); err != nil {
+			// Visiting the link may be all it takes to unsubscribe
+			m.dashboardMsg = "🔒  Not opening the unsubscribe link: " + err.Error()
+		} else {
+			if err := openBrowser(i.link); err != nil {
+				m.dashboardMsg = "❌  Failed to open browser: " + err.Error() + " | Link: " + hyperlink(i.link, i.link)
+			} else {
+				logAction("Opened the unsubscribe page of %s", i.title)
+				m.dashboardMsg = "🔗  Opening: " + hyperlink(i.link, i.link)
+			}
+		}
+	}
+	return m, nil
+}
+
 func (m appModel) submitLogin() tea.Cmd {
 	return func() tea.Msg {
 		email := strings.TrimSpace(m.loginInputs[0].Value())
@@ -1170,12 +1541,12 @@ func (m appModel) batchUnsubscribe() tea.Cmd {
 		}
 
 		for _, stat := range m.dashboardStats {
-			if m.dashboardSelected[stat.Sender] {
+			if m.dashboardSelected[stat.Sender.Key()] && !config.IsKept(stat.Sender, m.keepList, m.trustedDomains) {
 				requests = append(requests, struct {
 					Sender string
 					Link   string
 				}{
-					Sender: stat.Sender,
+					Sender: stat.Sender.Key(),
 					Link:   stat.Unsubscribe,
 				})
 			}
@@ -1186,21 +1557,22 @@ func (m appModel) batchUnsubscribe() tea.Cmd {
 		}
 
 		// Pass credentials for mailto: links
-		results := unsubscribe.BatchUnsubscribe(requests, m.savedEmail, m.savedPassword, m.savedServer)
+		email, password, server := m.dashboardCredentials()
+		results := unsubscribe.BatchUnsubscribe(requests, email, password, server)
 		return unsubscribeResultMsg{results: results}
 	}
 }
 
 func (m appModel) startAnalysis() tea.Cmd {
 	return func() tea.Msg {
-		// Get days
-		daysStr := strings.TrimSpace(m.analyzeInputs[0].Value())
-		if daysStr == "" {
-			daysStr = "30"
+		// Get period (e.g. "30", "2w", "3m", "all")
+		period := strings.TrimSpace(m.analyzeInputs[0].Value())
+		if period == "" {
+			period = "30"
 		}
-		daysInt, err := strconv.Atoi(daysStr)
-		if err != nil || daysInt <= 0 {
-			return errorMsg("Invalid number of days")
+		since, err := imap.ParseSearchWindow(period)
+		if err != nil {
+			return errorMsg(err.Error())
 		}
 
 		// Use saved credentials or input
@@ -1212,15 +1584,24 @@ func (m appModel) startAnalysis() tea.Cmd {
 			return errorMsg("Please login first")
 		}
 
-		days := time.Duration(daysInt) * 24 * time.Hour
-		since := time.Now().Add(-days)
+		// Analyze the configured folders (INBOX by default)
+		var folders []string
+		if settings, err := config.LoadSettings(); err == nil {
+			folders = settings.Folders
+		}
+
+		if m.analyzeAll {
+			return analyzeAllAccounts(since, folders)
+		}
 
-		stats, err := imap.FetchNewsletterStats(server, email, password, since)
+		stats, folderStats, err := imap.FetchFolderStats(server, email, password, since, folders)
 		if err != nil {
 			return errorMsg("Failed to fetch newsletters: " + err.Error())
 		}
+		recordHistory(email, since, stats)
+		suggestKeeps(email, stats)
 
-		return analysisCompleteMsg{stats: stats}
+		return analysisCompleteMsg{stats: stats, folders: folderStats, since: since}
 	}
 }
 
@@ -1231,7 +1612,10 @@ type loginSuccessMsg struct {
 }
 
 type analysisCompleteMsg struct {
-	stats []imap.NewsletterStat
+	stats    []imap.NewsletterStat
+	folders  []imap.FolderStat
+	since    time.Time
+	accounts []accountAnalysis // Per-account results when several accounts were analyzed
 }
 
 type errorMsg string
@@ -1241,6 +1625,10 @@ func (m appModel) View() string {
 		return "Initializing..."
 	}
 
+	if m.showPalette {
+		return m.viewPalette()
+	}
+
 	// Handle special messages in view (for async updates)
 	var view string
 
@@ -1267,6 +1655,12 @@ func (m appModel) View() string {
 		view = m.viewDeleteConfirm()
 	case screenSubscription:
 		view = m.viewSubscription()
+	case screenReview:
+		view = m.viewReview()
+	case screenKeepSuggestions:
+		view = m.viewKeepSuggestions()
+	case screenTeam:
+		view = m.viewTeam()
 	}
 
 	// Add error message if present
@@ -1278,13 +1672,20 @@ func (m appModel) View() string {
 		view += "\n" + errorStyle.Render("❌ "+m.errMsg)
 	}
 
+	if config.ReadOnly() {
+		view += "\n" + helpStyle.Render("🔒 Read-only mode: nothing will be changed")
+	}
+
 	return view
 }
 
 func (m appModel) viewWelcome() string {
 	intro := introStyle.Render(
 		"A beautiful TUI-based CLI to analyze, list and unsubscribe\nfrom newsletters using your IMAP inbox.",
-	)
+	) + "\n\n"
+	if m.compact() {
+		intro = ""
+	}
 
 	// Update title with version if available
 	if m.currentVersion != "" {
@@ -1293,6 +1694,9 @@ func (m appModel) viewWelcome() string {
 		premiumBadge := ""
 		if premiumConfig != nil && premiumConfig.Enabled {
 			premiumBadge = " ☁️"
+			if m.premiumTier != "" {
+				premiumBadge += " " + strings.ToUpper(m.premiumTier[:1]) + m.premiumTier[1:]
+			}
 		}
 		m.welcomeList.Title = fmt.Sprintf("📬  Newsletter CLI v%s%s", m.currentVersion, premiumBadge)
 	}
@@ -1310,7 +1714,7 @@ func (m appModel) viewWelcome() string {
 			MarginTop(1)
 		updateNotice = "\n" + updateStyle.Render(
 			fmt.Sprintf("✨ Update available: %s\n   Visit: %s",
-				m.updateAvailable.version, m.updateAvailable.url),
+				m.updateAvailable.version, hyperlink(m.updateAvailable.url, m.updateAvailable.url)),
 		)
 	}
 
@@ -1336,13 +1740,17 @@ func (m appModel) viewWelcome() string {
 		}
 	}
 
-	helpText := "[↑↓] Navigate  [Enter] Select  [q/Esc] Quit"
+	if banner := m.viewSessionExpired(); banner != "" {
+		syncStatusText = "\n" + banner
+	}
+
+	helpText := "[↑↓] Navigate  [Enter] Select  [Ctrl+P] Commands  [q/Esc] Quit"
 	if m.premiumEnabled {
-		helpText = "[↑↓] Navigate  [Enter] Select  [Ctrl+S] Sync  [q/Esc] Quit"
+		helpText = "[↑↓] Navigate  [Enter] Select  [Ctrl+P] Commands  [Ctrl+S] Sync  [q/Esc] Quit"
 	}
 	help := helpStyle.Render(helpText)
 
-	return docStyle.Render(intro + "\n\n" + listView + updateNotice + syncStatusText + "\n" + help)
+	return docStyle.Render(intro + listView + updateNotice + m.viewNoiseAlert() + syncStatusText + "\n" + help)
 }
 
 // formatTimeAgoSync formats time for sync status (shorter format)
@@ -1417,22 +1825,39 @@ func (m appModel) viewLogin() string {
 func (m appModel) viewAnalyzeInput() string {
 	title := titleStyle.Render("📊  Analyze Newsletters")
 
-	daysLabel := lipgloss.NewStyle().Width(20).Foreground(lipgloss.Color("240")).Render("📅 Days:")
+	daysLabel := lipgloss.NewStyle().Width(20).Foreground(lipgloss.Color("240")).Render("📅 Period:")
 	daysInput := m.analyzeInputs[0]
+	borderColor := lipgloss.Color("63")
+	if m.analyzeErr != "" {
+		borderColor = lipgloss.Color("196")
+	}
 	inputStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("63")).
+		BorderForeground(borderColor).
 		Padding(0, 1)
 
 	content := title + "\n\n" + daysLabel + " " + inputStyle.Render(daysInput.View())
 
+	// Inline validation or syntax hint
+	if m.analyzeErr != "" {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("⚠️  "+m.analyzeErr)
+	} else {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Days (30), weeks (2w), months (3m), years (1y) or all")
+	}
+
 	accountInfo := ""
-	if m.savedEmail != "" {
+	help := helpStyle.Render("[Enter] Analyze  [Esc] Back")
+	accounts, _ := config.GetAllAccounts()
+	if m.analyzeAll && len(accounts) > 1 {
+		accountStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).MarginTop(1)
+		accountInfo = "\n\n" + accountStyle.Render(fmt.Sprintf("👥 Analyzing all %d saved accounts", len(accounts)))
+	} else if m.savedEmail != "" {
 		accountStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).MarginTop(1)
 		accountInfo = "\n\n" + accountStyle.Render(fmt.Sprintf("🔐 Using saved account: %s @ %s", m.savedEmail, m.savedServer))
 	}
-
-	help := helpStyle.Render("[Enter] Analyze  [Esc] Back")
+	if len(accounts) > 1 {
+		help = helpStyle.Render("[Enter] Analyze  [Tab] This/All Accounts  [Esc] Back")
+	}
 
 	return docStyle.Render(content + accountInfo + "\n\n" + help)
 }
@@ -1470,13 +1895,36 @@ func (m appModel) viewDashboard() string {
 
 	selectedCount := len(m.dashboardSelected)
 	summaryText := fmt.Sprintf("Total: %d newsletters • %d emails", m.totalNewsletters, m.totalEmails)
+	tracking := 0
+	for _, stat := range m.dashboardStats {
+		if stat.TrackingHeavy() {
+			tracking++
+		}
+	}
+	if tracking > 0 {
+		summaryText += fmt.Sprintf(" • 👁 %d tracking heavy", tracking)
+	}
 	if selectedCount > 0 {
 		selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true)
 		summaryText += fmt.Sprintf(" • %s selected", selectedStyle.Render(fmt.Sprintf("%d", selectedCount)))
 	}
+	if label := m.dashboardAccountLabel(); label != "" {
+		summaryText = label + " • " + summaryText
+	}
 	summary := headerStyle.Render(summaryText)
+	if bar := m.viewSmartViewBar(); bar != "" {
+		summary += "\n" + bar
+	}
 
 	listView := docStyle.Render(m.dashboardList.View())
+	if m.showFolderHeatmap {
+		listView = docStyle.Render(m.viewFolderHeatmap())
+	} else if m.showArrivals {
+		listView = docStyle.Render(m.viewArrivals())
+	}
+	if m.showHints {
+		return summary + "\n" + docStyle.Render(m.viewHints())
+	}
 
 	status := ""
 	if m.dashboardMsg != "" {
@@ -1488,25 +1936,62 @@ func (m appModel) viewDashboard() string {
 		}
 		status = "\n" + msgStyle.Render(m.dashboardMsg)
 	}
+	if banner := m.viewSessionExpired(); banner != "" {
+		status += "\n" + lipgloss.NewStyle().Padding(0, 1).Render(banner)
+	}
 
-	helpText := "[↑↓] Navigate  [Space] Select  [u] Single  [U] Mass Unsubscribe  [/] Search  [Esc] Clear  [q] Quit"
+	helpParts := []string{"[↑↓] Navigate", "[Space] Select", "[u] Single", "[U] Mass Unsubscribe", "[/] Search"}
+	if len(m.smartViews) > 0 {
+		helpParts = append(helpParts, "[Tab] Views")
+	}
+	if len(m.dashboardAccounts) > 0 {
+		helpParts = append(helpParts, "[a] Accounts")
+	}
+	helpParts = append(helpParts, "[k] Keep", "[p] Preferences", "[n] Names", "[f] Folders", "[t] Times")
+	if item, ok := m.dashboardList.SelectedItem().(dashboardListItem); ok && item.categorized {
+		helpParts = append(helpParts, "[c] Category")
+	}
+	if m.premiumEnabled {
+		helpParts = append(helpParts, "[S] Share")
+	}
+	helpParts = append(helpParts, "[?] Tips", "[Esc] Clear", "[q] Quit")
+	helpText := strings.Join(helpParts, "  ")
 	if m.unsubscribing {
 		helpText = "[🔄 Unsubscribing... Please wait]"
 	}
+	if m.pendingConfirm == "frequency" {
+		helpText = "[y] Frequency reduced  [any other key] Not now"
+	} else if m.pendingConfirm == "snapshot" {
+		helpText = "[y] Upload  [any other key] Cancel"
+	} else if m.pendingConfirm != "" {
+		helpText = "[y] Unsubscribe  [any other key] Cancel"
+	}
 	help := helpStyle.Render(helpText)
 
 	return summary + "\n" + listView + status + "\n" + help
 }
 
 type dashboardListItem struct {
-	title        string
-	count        int
-	link         string
-	selected     bool   // Track if this item is selected
-	unsubscribed bool   // Track if this newsletter is already unsubscribed
-	category     string // Newsletter category (premium only)
-	qualityScore int    // Quality score 0-100 (premium only)
-	isPremium    bool   // Whether premium features should be shown
+	title         string                // Sender key, see config.SenderIdentity
+	sender        config.SenderIdentity // Who the newsletter comes from
+	addressFirst  bool                  // Show the address as the primary label
+	count         int
+	link          string
+	preferences   string   // Preference center link, if one was found
+	selected      bool     // Track if this item is selected
+	unsubscribed  bool     // Track if this newsletter is already unsubscribed
+	category      string   // Newsletter category, set by the categorizer
+	qualityScore  int      // Quality score 0-100, set by the categorizer
+	tags          []string // Category tags, set by the categorizer
+	predicted     string   // Category from enrichment, before any override
+	overridden    bool     // Category was assigned by hand
+	transactional bool     // Sender looks like receipts/security mail
+	trackingHeavy bool     // Most scanned emails carry open or click tracking
+	kept          bool     // On the keep list or from a trusted provider domain
+	categorized   bool     // A categorizer ran, so categories and scores can be shown
+	categorizing  bool     // Category not known yet, the categorizer is still running
+
+	frequencyReduced bool // Frequency was reduced in the preference center
 }
 
 func (i dashboardListItem) Title() string {
@@ -1524,26 +2009,28 @@ func (i dashboardListItem) Title() string {
 
 	// Add quality score stars (⭐) for high scores (premium only)
 	stars := ""
-	if i.isPremium && i.qualityScore >= 80 {
+	if i.categorized && i.qualityScore >= 80 {
 		stars = " ⭐⭐⭐⭐⭐"
-	} else if i.isPremium && i.qualityScore >= 70 {
+	} else if i.categorized && i.qualityScore >= 70 {
 		stars = " ⭐⭐⭐⭐"
-	} else if i.isPremium && i.qualityScore >= 60 {
+	} else if i.categorized && i.qualityScore >= 60 {
 		stars = " ⭐⭐⭐"
-	} else if i.isPremium && i.qualityScore >= 50 {
+	} else if i.categorized && i.qualityScore >= 50 {
 		stars = " ⭐⭐"
-	} else if i.isPremium && i.qualityScore >= 40 {
+	} else if i.categorized && i.qualityScore >= 40 {
 		stars = " ⭐"
 	}
 
+	label, _ := i.senderLabels()
+
 	// Style unsubscribed items differently
 	var titleStyle lipgloss.Style
 	if i.unsubscribed {
 		titleStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Strikethrough(true)
-		return prefix + titleStyle.Render(i.title) + stars + "  " + countStyle.Render(fmt.Sprintf("(%s)", countStr))
+		return prefix + titleStyle.Render(label) + stars + "  " + countStyle.Render(fmt.Sprintf("(%s)", countStr))
 	}
 
-	return prefix + i.title + stars + "  " + countStyle.Render(fmt.Sprintf("(%s)", countStr))
+	return prefix + label + stars + "  " + countStyle.Render(fmt.Sprintf("(%s)", countStr))
 }
 
 func (i dashboardListItem) Description() string {
@@ -1551,14 +2038,17 @@ func (i dashboardListItem) Description() string {
 	if i.count != 1 {
 		desc += "s"
 	}
+	if _, secondary := i.senderLabels(); secondary != "" {
+		desc = senderAddressStyle.Render(secondary) + "  •  " + desc
+	}
 
 	// Show unsubscribed status
 	if i.unsubscribed {
 		status := desc + "  •  ✅ Already unsubscribed"
-		if i.isPremium && i.category != "" {
+		if i.categorized && i.category != "" {
 			status += "  •  📂 " + i.category
 		}
-		if i.isPremium && i.qualityScore > 0 {
+		if i.categorized && i.qualityScore > 0 {
 			status += fmt.Sprintf("  •  Score: %d/100", i.qualityScore)
 		}
 		return status
@@ -1568,13 +2058,39 @@ func (i dashboardListItem) Description() string {
 	var parts []string
 	parts = append(parts, desc)
 
+	// Protected senders can't be selected
+	if i.kept {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render("🛡️ Kept"))
+	}
+
+	if i.frequencyReduced {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("141")).Render("🔉 Frequency reduced"))
+	} else if i.preferences != "" {
+		parts = append(parts, "⚙️ Preferences")
+	}
+
+	if i.trackingHeavy {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("204")).Render("👁 Tracking heavy"))
+	}
+
+	// Warn about transactional senders
+	if i.transactional {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("🔒 Transactional"))
+	}
+
 	// Add category (premium only)
-	if i.isPremium && i.category != "" {
-		parts = append(parts, "📂 "+i.category)
+	if i.categorizing {
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("📂 categorizing…"))
+	} else if i.categorized && i.category != "" {
+		if i.overridden {
+			parts = append(parts, "📂 "+i.category+" ✎")
+		} else {
+			parts = append(parts, "📂 "+i.category)
+		}
 	}
 
 	// Add quality score (premium only)
-	if i.isPremium && i.qualityScore > 0 {
+	if i.categorized && i.qualityScore > 0 {
 		var scoreColor lipgloss.Color
 		if i.qualityScore >= 80 {
 			scoreColor = lipgloss.Color("10") // Green
@@ -1593,7 +2109,7 @@ func (i dashboardListItem) Description() string {
 		if len(linkDisplay) > 40 {
 			linkDisplay = linkDisplay[:37] + "..."
 		}
-		parts = append(parts, "🔗 "+linkDisplay)
+		parts = append(parts, "🔗 "+hyperlink(i.link, linkDisplay))
 	} else {
 		parts = append(parts, "⚠️  No unsubscribe link")
 	}
@@ -1601,7 +2117,7 @@ func (i dashboardListItem) Description() string {
 	return strings.Join(parts, "  •  ")
 }
 
-func (i dashboardListItem) FilterValue() string { return i.title }
+func (i dashboardListItem) FilterValue() string { return i.title + " " + i.sender.Name }
 
 var (
 	titleStyle = lipgloss.NewStyle().
@@ -1643,6 +2159,30 @@ func (i accountListItem) FilterValue() string {
 	return i.account.Name + " " + i.account.Email
 }
 
+// deleteSelectedAccount deletes the account marked for deletion
+func (m appModel) deleteSelectedAccount() (tea.Model, tea.Cmd) {
+	deleted := m.accountToDelete
+	for _, acc := range m.accounts {
+		if acc.ID == m.accountToDelete {
+			deleted = acc.Email
+		}
+	}
+	if err := config.DeleteAccount(m.accountToDelete); err != nil {
+		m.accountsMsg = "❌ Failed to delete account: " + err.Error()
+	} else {
+		logAction("Deleted account %s", deleted)
+		m.accountsMsg = "✅ Account deleted"
+		// Reload accounts
+		accounts, _ := config.GetAllAccounts()
+		m.accounts = accounts
+		// Reinitialize list
+		return m.initAccountsList()
+	}
+	m.deleteConfirming = false
+	m.accountToDelete = ""
+	return m, nil
+}
+
 // initAccountsList initializes the accounts list
 func (m appModel) initAccountsList() (tea.Model, tea.Cmd) {
 	items := []list.Item{}
@@ -1650,26 +2190,11 @@ func (m appModel) initAccountsList() (tea.Model, tea.Cmd) {
 		items = append(items, accountListItem{account: acc})
 	}
 
-	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("229")).
-		Bold(true)
-	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("219"))
-
-	l := list.New(items, delegate, 0, 0)
-	l.Title = "👤  Manage Accounts"
-	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(true)
-	l.Styles.Title = lipgloss.NewStyle().
-		Background(lipgloss.Color("63")).
-		Foreground(lipgloss.Color("230")).
-		Bold(true).
-		Padding(0, 1)
+	l := newList(items, "👤  Manage Accounts", true)
 
 	h, v := docStyle.GetFrameSize()
 	if m.width > 0 && m.height > 0 {
-		l.SetSize(m.width-h, m.height-v-7)
+		l.SetSize(m.width-h, m.height-v-m.listChrome()+1)
 	}
 
 	m.accountsList = l
@@ -1685,7 +2210,7 @@ func (m appModel) updateAccounts(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		h, v := docStyle.GetFrameSize()
-		m.accountsList.SetSize(msg.Width-h, msg.Height-v-7)
+		m.accountsList.SetSize(msg.Width-h, msg.Height-v-m.listChrome()+1)
 		return m, nil
 
 	case tea.KeyMsg:
@@ -1706,20 +2231,7 @@ func (m appModel) updateAccounts(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "enter":
 			if m.deleteConfirming {
-				// Confirm deletion
-				if err := config.DeleteAccount(m.accountToDelete); err != nil {
-					m.accountsMsg = "❌ Failed to delete account: " + err.Error()
-				} else {
-					m.accountsMsg = "✅ Account deleted"
-					// Reload accounts
-					accounts, _ := config.GetAllAccounts()
-					m.accounts = accounts
-					// Reinitialize list
-					return m.initAccountsList()
-				}
-				m.deleteConfirming = false
-				m.accountToDelete = ""
-				return m, nil
+				return m.deleteSelectedAccount()
 			}
 			// Select account
 			i, ok := m.accountsList.SelectedItem().(accountListItem)
@@ -1728,6 +2240,7 @@ func (m appModel) updateAccounts(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.accountsMsg = "❌ Failed to select account: " + err.Error()
 				} else {
 					m.accountsMsg = "✅ Selected account: " + i.account.Name
+					logAction("Switched to account %s", i.account.Email)
 
 					// Update saved credentials to the selected account
 					m.savedEmail = i.account.Email
@@ -1790,6 +2303,9 @@ func (m appModel) updateAccounts(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				m.accountToDelete = i.account.ID
 				m.deleteConfirming = true
+				if !config.ShouldConfirm(true) {
+					return m.deleteSelectedAccount()
+				}
 				m.accountsMsg = fmt.Sprintf("⚠️  Delete %s? Press Enter to confirm, Esc to cancel", i.account.Name)
 			}
 			return m, nil
@@ -1863,8 +2379,11 @@ func (m appModel) viewAccounts() string {
 
 // RunAppSync runs the app synchronously (for use from commands)
 // initialScreen can be "login", "analyze", or "" for welcome
-func RunAppSync(savedEmail, savedPassword, savedServer string, days int, flagsProvided bool, initialScreen string, currentVersion string) error {
+func RunAppSync(savedEmail, savedPassword, savedServer string, period string, flagsProvided bool, initialScreen string, currentVersion string) error {
 	m := NewAppModel(savedEmail, savedPassword, savedServer, currentVersion)
+	if !config.ReadOnly() {
+		transcript = config.NewTranscript(time.Now())
+	}
 
 	// Determine initial screen
 	if initialScreen == "login" {
@@ -1877,10 +2396,10 @@ func RunAppSync(savedEmail, savedPassword, savedServer string, days int, flagsPr
 		// Go directly to analyze input or analysis
 		if savedEmail != "" && savedPassword != "" && savedServer != "" {
 			m.screen = screenAnalyzing
-			if days == 0 {
-				days = 30
+			if period == "" {
+				period = "30"
 			}
-			m.analyzeInputs[0].SetValue(strconv.Itoa(days))
+			m.analyzeInputs[0].SetValue(period)
 			m.savedEmail = savedEmail
 			m.savedPassword = savedPassword
 			m.savedServer = savedServer
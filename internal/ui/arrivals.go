@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/imap"
+)
+
+// sparkBlocks go from lowest to highest bar
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as a row of block characters scaled to the maximum
+func sparkline(values []int) string {
+	maxValue := 0
+	for _, v := range values {
+		if v > maxValue {
+			maxValue = v
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if maxValue == 0 || v == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		idx := v * (len(sparkBlocks) - 1) / maxValue
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+// peakIndex returns the index of the largest value, or -1 if all are zero
+func peakIndex(values []int) int {
+	peak, maxValue := -1, 0
+	for i, v := range values {
+		if v > maxValue {
+			peak, maxValue = i, v
+		}
+	}
+	return peak
+}
+
+// arrivalChart renders hour-of-day and day-of-week sparklines with the peaks
+func arrivalChart(label string, byHour [24]int, byWeekday [7]int) string {
+	sparkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("63"))
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	labelStyle := lipgloss.NewStyle().Width(8).Foreground(lipgloss.Color("240"))
+
+	// Start the week on Monday for display
+	weekdays := []int{byWeekday[1], byWeekday[2], byWeekday[3], byWeekday[4], byWeekday[5], byWeekday[6], byWeekday[0]}
+
+	hourLine := labelStyle.Render("Hour") + sparkStyle.Render(sparkline(byHour[:]))
+	hourAxis := labelStyle.Render("") + dimStyle.Render("0     6     12    18   23")
+	dayLine := labelStyle.Render("Day") + sparkStyle.Render(sparkline(weekdays))
+	dayAxis := labelStyle.Render("") + dimStyle.Render("MTWTFSS")
+
+	peaks := ""
+	if h := peakIndex(byHour[:]); h >= 0 {
+		peaks = fmt.Sprintf("Busiest: %02d:00–%02d:00", h, (h+1)%24)
+		if d := peakIndex(byWeekday[:]); d >= 0 {
+			peaks += " on " + time.Weekday(d).String() + "s"
+		}
+	}
+
+	return headerStyle.Render(label) + "\n" +
+		hourLine + "\n" + hourAxis + "\n" +
+		dayLine + "\n" + dayAxis + "\n" +
+		dimStyle.Render(peaks)
+}
+
+// viewArrivals renders the overall arrival pattern and the one of the
+// highlighted newsletter
+func (m appModel) viewArrivals() string {
+	title := titleStyle.Render("🕒  When Newsletters Arrive")
+
+	var totalHour [24]int
+	var totalWeekday [7]int
+	var selected *imap.NewsletterStat
+	current, _ := m.dashboardList.SelectedItem().(dashboardListItem)
+	for idx, s := range m.dashboardStats {
+		for h, n := range s.ByHour {
+			totalHour[h] += n
+		}
+		for d, n := range s.ByWeekday {
+			totalWeekday[d] += n
+		}
+		if s.Sender.Key() == current.title {
+			selected = &m.dashboardStats[idx]
+		}
+	}
+
+	content := title + "\n" + arrivalChart("All newsletters", totalHour, totalWeekday)
+	if selected != nil {
+		content += "\n" + arrivalChart(selected.Sender.Label(), selected.ByHour, selected.ByWeekday)
+	}
+	return content
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// categoryFeedbackDelay lets the user cycle through categories before the
+// final choice is sent as feedback
+const categoryFeedbackDelay = 3 * time.Second
+
+// categoryFeedbackMsg fires after an edit settles; seq guards against
+// sending feedback for an intermediate choice
+type categoryFeedbackMsg struct {
+	sender    string
+	predicted string
+	category  string
+	seq       int
+}
+
+// cycleCategory moves the newsletter to the next category, or back to the
+// predicted one when reset is set, and persists the override
+func (m appModel) cycleCategory(item dashboardListItem, reset bool) (tea.Model, tea.Cmd) {
+	next := item.predicted
+	if !reset {
+		next = api.NewsletterCategories[0]
+		for idx, c := range api.NewsletterCategories {
+			if c == item.category {
+				next = api.NewsletterCategories[(idx+1)%len(api.NewsletterCategories)]
+				break
+			}
+		}
+	}
+
+	// Choosing the predicted category again simply drops the override
+	override := next
+	if next == item.predicted {
+		override = ""
+	}
+	if err := config.SetCategoryOverride(item.title, override); err != nil {
+		m.dashboardMsg = "❌  Failed to save category: " + err.Error()
+		return m, nil
+	}
+
+	m.setDashboardItem(item.title, func(i *dashboardListItem) {
+		i.category = next
+		i.overridden = override != ""
+	})
+	m.dashboardMsg = "📂 " + item.title + " → " + next
+
+	// Debounce feedback so only the settled choice is sent. Every edit
+	// counts, so going back to the prediction cancels pending feedback.
+	if m.categoryEdits == nil {
+		m.categoryEdits = make(map[string]int)
+	}
+	m.categoryEdits[item.title]++
+	// Feedback improves the remote categorizer, so it is a premium feature
+	if override == "" || !m.premiumEnabled {
+		return m, nil
+	}
+	msg := categoryFeedbackMsg{sender: item.title, predicted: item.predicted, category: next, seq: m.categoryEdits[item.title]}
+	return m, tea.Tick(categoryFeedbackDelay, func(time.Time) tea.Msg { return msg })
+}
+
+// sendCategoryFeedback submits the override if it is still the latest edit
+func (m appModel) sendCategoryFeedback(msg categoryFeedbackMsg) tea.Cmd {
+	if m.categoryEdits[msg.sender] != msg.seq {
+		return nil
+	}
+	return func() tea.Msg {
+		_ = api.SubmitCategoryFeedback(msg.sender, msg.predicted, msg.category) // Opt-in, best effort
+		return nil
+	}
+}
+
+// setDashboardItem applies update to the dashboard item for sender in both
+// the full item set and the visible list
+func (m *appModel) setDashboardItem(sender string, update func(*dashboardListItem)) {
+	for idx, item := range m.dashboardItems {
+		if item, ok := item.(dashboardListItem); ok && item.title == sender {
+			update(&item)
+			m.dashboardItems[idx] = item
+		}
+	}
+	items := m.dashboardList.Items()
+	for idx, item := range items {
+		if item, ok := item.(dashboardListItem); ok && item.title == sender {
+			update(&item)
+			m.dashboardList.SetItem(idx, item)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// compactHeight is the terminal height below which screens are rendered
+// compactly so help and status lines are not clipped
+const compactHeight = 20
+
+// compact reports whether the terminal is too short for the regular layout
+func (m appModel) compact() bool {
+	return m.height > 0 && m.height < compactHeight
+}
+
+// listChrome is the number of lines screens with a list spend on their
+// header, status and help lines
+func (m appModel) listChrome() int {
+	if m.compact() {
+		return 6
+	}
+	return 8
+}
+
+// applyLayout switches the shared styles between the regular and the
+// compact layout, which drops the outer margins and the blank lines around
+// headers and help text
+func applyLayout(compact bool) {
+	if compact {
+		docStyle = docStyle.Margin(0, 1)
+		headerStyle = headerStyle.Margin(0, 0)
+		helpStyle = helpStyle.MarginTop(0)
+		return
+	}
+	docStyle = docStyle.Margin(1, 2)
+	headerStyle = headerStyle.Margin(1, 0)
+	helpStyle = helpStyle.MarginTop(1)
+}
+
+// collapseBlankLines removes the empty lines used to separate sections
+func collapseBlankLines(content string) string {
+	lines := strings.Split(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// paginate cuts content into pages of height lines and returns the given
+// page, clamped to the last one, together with the number of pages
+func paginate(content string, page, height int) (string, int) {
+	lines := strings.Split(content, "\n")
+	if height < 1 {
+		height = 1
+	}
+	pages := (len(lines) + height - 1) / height
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	end := (page + 1) * height
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return strings.Join(lines[page*height:end], "\n"), pages
+}
+
+// pageIndicator renders the position in paginated content
+func pageIndicator(page, pages int) string {
+	if page >= pages {
+		page = pages - 1
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).
+		Render(fmt.Sprintf("Page %d/%d [PgUp/PgDn]", page+1, pages))
+}
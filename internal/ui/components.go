@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Shared styles of the app's screens
+var (
+	docStyle = lipgloss.NewStyle().Margin(1, 2)
+
+	headerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Margin(1, 0).
+			Bold(true)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			MarginTop(1)
+
+	emptyStateStyle = lipgloss.NewStyle().
+			Align(lipgloss.Center).
+			Padding(2, 4).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("238"))
+
+	listTitleStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("63")).
+			Foreground(lipgloss.Color("230")).
+			Bold(true).
+			Padding(0, 1)
+)
+
+// newListDelegate returns the item delegate every list in the app uses
+func newListDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(lipgloss.Color("229")).
+		Bold(true)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
+		Foreground(lipgloss.Color("219"))
+	return delegate
+}
+
+// newList creates a list with the app's title bar and item styles. Sizing
+// is left to the caller.
+func newList(items []list.Item, title string, filtering bool) list.Model {
+	l := list.New(items, newListDelegate(), 0, 0)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(filtering)
+	l.Styles.Title = listTitleStyle
+	return l
+}
+
+// getCountColor colors an email count from green to red by volume
+func getCountColor(count int) lipgloss.Color {
+	if count >= 20 {
+		return lipgloss.Color("196") // Red for high counts
+	} else if count >= 10 {
+		return lipgloss.Color("208") // Orange
+	} else if count >= 5 {
+		return lipgloss.Color("220") // Yellow
+	}
+	return lipgloss.Color("46") // Green for low counts
+}
+
+// openBrowser opens url in the default browser without waiting for it
+func openBrowser(url string) error {
+	var cmd string
+	var args []string
+
+	switch runtime.GOOS {
+	case "linux":
+		cmd = "xdg-open"
+		args = []string{url}
+	case "windows":
+		cmd = "rundll32"
+		args = []string{"url.dll,FileProtocolHandler", url}
+	case "darwin":
+		cmd = "open"
+		args = []string{url}
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+
+	browserCmd := exec.Command(cmd, args...)
+
+	if err := browserCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start browser: %w", err)
+	}
+
+	// Detach the process so it doesn't block
+	go func() {
+		if browserCmd.Process != nil {
+			browserCmd.Process.Release()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// enrichBatchSize is how many newsletters are categorized at a time, so the
+// dashboard fills in while the rest are still being worked on
+const enrichBatchSize = 25
+
+// enrichmentMsg carries the categories of one batch of newsletters
+type enrichmentMsg struct {
+	seq         int
+	categorizer api.Categorizer // Nil when newsletters are not categorized
+	err         error           // Why the categorizer could not be set up
+	enriched    []api.EnrichNewsletter
+	pending     []api.EnrichNewsletterInput // Still to be categorized
+	overrides   map[string]string           // Categories the user assigned by hand
+}
+
+// startEnrichment sets up the configured categorizer and categorizes the
+// first batch. The remote one needs an active subscription, the offline
+// ones work for everyone.
+func startEnrichment(seq int, inputs []api.EnrichNewsletterInput, settings *config.Settings, overrides map[string]string, tier string) tea.Cmd {
+	return func() tea.Msg {
+		hasSubscription := false
+		if settings == nil || settings.Categorizer == "" || settings.Categorizer == api.CategorizerRemote {
+			if license, _ := api.LoadOfflineLicense(time.Now()); license != nil {
+				// The features come from the offline license, the enrichment
+				// API is out of reach
+				return categorizeBatch(seq, api.OfflineCategorizer(settings), inputs, overrides)
+			}
+			if tier != "" {
+				// Already loaded at startup
+				hasSubscription = tier != "free"
+			} else if pc, _ := api.GetPremiumConfig(); pc != nil && pc.Enabled {
+				// Fetching features validates the subscription
+				if features, err := api.GetLicenseFeatures(); err == nil {
+					tier, _ := features["tier"].(string)
+					hasSubscription = tier != "" && tier != "free"
+				}
+			}
+		}
+
+		categorizer, err := api.NewCategorizer(settings, hasSubscription)
+		if err != nil || categorizer == nil {
+			return enrichmentMsg{seq: seq, err: err}
+		}
+		return categorizeBatch(seq, categorizer, inputs, overrides)
+	}
+}
+
+// categorizeBatch categorizes the next batch of inputs
+func categorizeBatch(seq int, categorizer api.Categorizer, inputs []api.EnrichNewsletterInput, overrides map[string]string) enrichmentMsg {
+	n := min(enrichBatchSize, len(inputs))
+	// A failed batch is shown without categories rather than failing the rest
+	enriched, _ := categorizer.Categorize(inputs[:n])
+	return enrichmentMsg{
+		seq:         seq,
+		categorizer: categorizer,
+		enriched:    enriched,
+		pending:     inputs[n:],
+		overrides:   overrides,
+	}
+}
+
+// handleEnrichment patches the dashboard items of a categorized batch and
+// requests the next one
+func (m appModel) handleEnrichment(msg enrichmentMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.enrichSeq {
+		return m, nil
+	}
+
+	if msg.categorizer == nil {
+		if msg.err != nil {
+			m.dashboardMsg = "⚠️  Categorizer unavailable: " + msg.err.Error()
+		}
+		m.finishEnrichment(false, nil)
+		return m, nil
+	}
+
+	for _, e := range msg.enriched {
+		override, overridden := msg.overrides[e.Sender]
+		m.setDashboardItem(e.Sender, func(i *dashboardListItem) {
+			i.predicted = e.Category.Category
+			i.category = e.Category.Category
+			if overridden {
+				i.category = override
+			}
+			i.overridden = overridden
+			i.qualityScore = e.QualityScore
+			i.tags = e.Category.Tags
+			i.categorized = true
+			i.categorizing = false
+		})
+	}
+
+	if len(msg.pending) == 0 {
+		m.finishEnrichment(true, msg.overrides)
+		return m, nil
+	}
+
+	seq, categorizer, pending, overrides := msg.seq, msg.categorizer, msg.pending, msg.overrides
+	return m, func() tea.Msg {
+		return categorizeBatch(seq, categorizer, pending, overrides)
+	}
+}
+
+// finishEnrichment clears the placeholders of newsletters the categorizer
+// returned nothing for, which can still be categorized by hand, and
+// re-applies a smart view that filters by category
+func (m *appModel) finishEnrichment(categorized bool, overrides map[string]string) {
+	for idx, item := range m.dashboardItems {
+		if item, ok := item.(dashboardListItem); ok && item.categorizing {
+			item.categorizing = false
+			item.categorized = categorized
+			if override, ok := overrides[item.title]; ok && categorized {
+				item.category = override
+				item.overridden = true
+			}
+			m.dashboardItems[idx] = item
+		}
+	}
+
+	cursor := m.dashboardList.Index()
+	m.applySmartView()
+	m.dashboardList.Select(cursor)
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// heatmapWidth is the number of cells in a folder's heat bar
+const heatmapWidth = 20
+
+// heatShades go from cold to hot
+var heatShades = []struct {
+	char  string
+	color lipgloss.Color
+}{
+	{"░", lipgloss.Color("238")},
+	{"▒", lipgloss.Color("34")},
+	{"▓", lipgloss.Color("214")},
+	{"█", lipgloss.Color("196")},
+}
+
+// viewFolderHeatmap renders one row per analyzed folder. The bar length
+// shows the folder's share of newsletter emails, its shade the share of the
+// folder that is newsletters.
+func (m appModel) viewFolderHeatmap() string {
+	title := titleStyle.Render("🗂️  Newsletters by Folder")
+	if len(m.dashboardFolders) == 0 {
+		return title + "\n\n" + helpStyle.Render("No folder data for this analysis")
+	}
+
+	maxEmails := 0
+	nameWidth := 6
+	for _, f := range m.dashboardFolders {
+		if f.NewsletterEmails > maxEmails {
+			maxEmails = f.NewsletterEmails
+		}
+		if len(f.Name) > nameWidth {
+			nameWidth = len(f.Name)
+		}
+	}
+
+	nameStyle := lipgloss.NewStyle().Width(nameWidth + 2)
+	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var rows []string
+	for _, f := range m.dashboardFolders {
+		if f.Err != nil {
+			rows = append(rows, nameStyle.Render(f.Name)+dimStyle.Render("⚠️  "+f.Err.Error()))
+			continue
+		}
+
+		cells := 0
+		if maxEmails > 0 {
+			cells = f.NewsletterEmails * heatmapWidth / maxEmails
+		}
+		if cells == 0 && f.NewsletterEmails > 0 {
+			cells = 1
+		}
+
+		density := 0.0
+		if f.Emails > 0 {
+			density = float64(f.NewsletterEmails) / float64(f.Emails)
+		}
+		shade := heatShades[int(density*float64(len(heatShades)-1)+0.5)]
+
+		bar := lipgloss.NewStyle().Foreground(shade.color).Render(strings.Repeat(shade.char, cells)) +
+			dimStyle.Render(strings.Repeat("·", heatmapWidth-cells))
+		rows = append(rows, fmt.Sprintf("%s%s  %d newsletters • %d of %d emails (%.0f%%)",
+			nameStyle.Render(f.Name), bar, f.Newsletters, f.NewsletterEmails, f.Emails, density*100))
+	}
+
+	legend := dimStyle.Render("Length: newsletter emails  •  Shade: newsletter share of folder (░ low → █ high)")
+	return title + "\n\n" + strings.Join(rows, "\n") + "\n\n" + legend
+}
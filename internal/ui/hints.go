@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// dashboardHint is one step of the first-run guide
+type dashboardHint struct {
+	title string
+	text  string
+}
+
+// dashboardHints walk a new user through the dashboard, one feature at a time
+var dashboardHints = []dashboardHint{
+	{"Select newsletters", "Move with ↑↓ and press Space to select a newsletter.\nSelected ones are marked with ✓."},
+	{"Single unsubscribe", "Press u to unsubscribe from the highlighted newsletter.\nThis opens its unsubscribe link right away."},
+	{"Mass unsubscribe", "Press U to unsubscribe from everything you selected\nin one go. Results show up at the bottom."},
+	{"Filter the list", "Press / and start typing to narrow the list down.\nEsc clears the filter and your selection."},
+	{"Keep list", "Press k on a sender you want to keep. Kept senders\nare skipped by mass unsubscribe and can't be selected."},
+}
+
+var hintBoxStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("63")).
+	Padding(1, 2)
+
+// updateHints handles keys while the hints overlay is shown
+func (m appModel) updateHints(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "enter", "right", "l", " ":
+		if m.hintStep < len(dashboardHints)-1 {
+			m.hintStep++
+			return m, nil
+		}
+		m.dismissHints()
+	case "left", "h":
+		if m.hintStep > 0 {
+			m.hintStep--
+		}
+	case "esc", "q":
+		m.dismissHints()
+	}
+	return m, nil
+}
+
+// dismissHints closes the overlay and remembers it was seen
+func (m *appModel) dismissHints() {
+	m.showHints = false
+	m.hintStep = 0
+	if settings, err := config.LoadSettings(); err == nil && !settings.HintsSeen {
+		settings.HintsSeen = true
+		_ = config.SaveSettings(settings) // Worst case the hints show again
+	}
+}
+
+// viewHints renders the current hint step
+func (m appModel) viewHints() string {
+	hint := dashboardHints[m.hintStep]
+	stepStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	next := "[Enter] Next"
+	if m.hintStep == len(dashboardHints)-1 {
+		next = "[Enter] Done"
+	}
+
+	content := headerStyle.Render("💡 "+hint.title) + "  " +
+		stepStyle.Render(fmt.Sprintf("%d/%d", m.hintStep+1, len(dashboardHints))) + "\n\n" +
+		hint.text + "\n\n" +
+		helpStyle.Render(next+"  [←] Back  [Esc] Skip tips")
+	return hintBoxStyle.Render(content)
+}
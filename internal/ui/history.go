@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+)
+
+// recordHistory adds an analysis to the encrypted local history and to the
+// session transcript. History is a nice-to-have, so failures are ignored.
+func recordHistory(email string, since time.Time, stats []imap.NewsletterStat) {
+	entry := config.HistoryEntry{
+		Time:        time.Now(),
+		Account:     email,
+		Since:       since,
+		Newsletters: len(stats),
+		Senders:     make([]config.HistorySender, 0, len(stats)),
+	}
+	for _, s := range stats {
+		entry.Emails += s.Count
+		entry.Senders = append(entry.Senders, config.HistorySender{Sender: s.Sender.Key(), Count: s.Count})
+	}
+	_ = config.RecordAnalysis(entry)
+
+	window := "all mail"
+	if !since.IsZero() {
+		window = "since " + since.Format("2006-01-02")
+	}
+	logAction("Analyzed %s (%s): %d newsletters, %d emails", email, window, entry.Newsletters, entry.Emails)
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	hyperlinksOnce    sync.Once
+	hyperlinksEnabled bool
+)
+
+// hyperlink makes text a clickable link to url in terminals that support
+// OSC 8 hyperlinks and returns text unchanged elsewhere
+func hyperlink(url, text string) string {
+	if url == "" || !hyperlinksSupported() {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// hyperlinksSupported guesses OSC 8 support from the environment.
+// NEWSLETTER_CLI_HYPERLINKS=1 or =0 overrides the guess.
+func hyperlinksSupported() bool {
+	hyperlinksOnce.Do(func() {
+		hyperlinksEnabled = detectHyperlinks(os.Getenv)
+	})
+	return hyperlinksEnabled
+}
+
+func detectHyperlinks(getenv func(string) string) bool {
+	if v := getenv("NEWSLETTER_CLI_HYPERLINKS"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		return err == nil && enabled
+	}
+
+	// Multiplexers swallow the sequence unless configured to pass it through
+	if getenv("TMUX") != "" || strings.HasPrefix(getenv("TERM"), "screen") {
+		return false
+	}
+	if term := getenv("TERM"); term == "" || term == "dumb" {
+		return false
+	}
+
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty", "Tabby", "rio":
+		return true
+	}
+	if getenv("WT_SESSION") != "" || getenv("KITTY_WINDOW_ID") != "" || getenv("ALACRITTY_WINDOW_ID") != "" {
+		return true
+	}
+	if strings.Contains(getenv("TERM"), "kitty") || strings.Contains(getenv("TERM"), "alacritty") {
+		return true
+	}
+	// GNOME Terminal, Tilix and other VTE terminals since 0.50
+	if vte, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	return getenv("KONSOLE_VERSION") != ""
+}
@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+)
+
+const (
+	// Senders with fewer messages don't give a meaningful read rate
+	minKeepSuggestionEmails = 3
+	// Share of messages opened that makes a sender worth keeping
+	keepSuggestionReadRate = 0.8
+	maxKeepSuggestions     = 10
+)
+
+// suggestKeeps refreshes the keep-list suggestions of the account from an
+// analysis, at most once a week. Like history, suggestions are a
+// nice-to-have, so failures are ignored.
+//
+// Engagement is read from the IMAP flags. Link clicks never reach the
+// mailbox (the tracking detection only sees that links are wrapped, not
+// that they were followed), so a sender the user starred counts where a
+// click would.
+func suggestKeeps(email string, stats []imap.NewsletterStat) {
+	store, err := config.LoadKeepSuggestions()
+	if err != nil || !store.Due(email, time.Now()) {
+		return
+	}
+
+	var keepList, trusted []string
+	if settings, err := config.LoadSettings(); err == nil {
+		keepList = settings.KeepList
+	}
+	if accounts, err := config.GetAllAccounts(); err == nil {
+		trusted = config.TrustedDomains(accounts)
+	}
+	unsubscribed := map[string]bool{}
+	if unsubs, err := config.LoadUnsubscribed(); err == nil {
+		for _, n := range unsubs.Newsletters {
+			unsubscribed[config.SenderKey(n.Sender)] = true
+		}
+	}
+
+	var engaged []imap.NewsletterStat
+	for _, s := range stats {
+		if s.Count < minKeepSuggestionEmails || s.Transactional {
+			continue
+		}
+		if unsubscribed[s.Sender.Key()] || config.IsKept(s.Sender, keepList, trusted) {
+			continue
+		}
+		if s.ReadRate() >= keepSuggestionReadRate || s.RepliedEmails > 0 || s.StarredEmails > 0 {
+			engaged = append(engaged, s)
+		}
+	}
+
+	// Replies and stars are deliberate, so they rank above merely opening
+	sort.Slice(engaged, func(i, j int) bool {
+		return engagementScore(engaged[i]) > engagementScore(engaged[j])
+	})
+	if len(engaged) > maxKeepSuggestions {
+		engaged = engaged[:maxKeepSuggestions]
+	}
+
+	suggestions := make([]config.KeepSuggestion, 0, len(engaged))
+	for _, s := range engaged {
+		suggestions = append(suggestions, config.KeepSuggestion{
+			Sender:  s.Sender.Key(),
+			Count:   s.Count,
+			Read:    s.ReadEmails,
+			Replied: s.RepliedEmails,
+			Starred: s.StarredEmails,
+		})
+	}
+	store.Replace(email, suggestions, time.Now())
+	_ = config.SaveKeepSuggestions(store)
+}
+
+func engagementScore(s imap.NewsletterStat) float64 {
+	score := s.ReadRate()
+	if s.Count > 0 {
+		score += 2 * float64(s.RepliedEmails+s.StarredEmails) / float64(s.Count)
+	}
+	return score
+}
+
+// keepSuggestionItem is a sender suggested for the keep list
+type keepSuggestionItem struct {
+	suggestion config.KeepSuggestion
+}
+
+func (i keepSuggestionItem) Title() string {
+	countStyle := lipgloss.NewStyle().Foreground(getCountColor(i.suggestion.Count)).Bold(true)
+	return i.suggestion.Sender + "  " + countStyle.Render(fmt.Sprintf("(%d)", i.suggestion.Count))
+}
+
+func (i keepSuggestionItem) Description() string {
+	s := i.suggestion
+	var reasons []string
+	if s.Count > 0 {
+		reasons = append(reasons, fmt.Sprintf("You open %d%% of these", s.Read*100/s.Count))
+	}
+	if s.Replied > 0 {
+		reasons = append(reasons, fmt.Sprintf("replied %d×", s.Replied))
+	}
+	if s.Starred > 0 {
+		reasons = append(reasons, fmt.Sprintf("starred %d×", s.Starred))
+	}
+	return strings.Join(reasons, "  •  ")
+}
+
+func (i keepSuggestionItem) FilterValue() string { return i.suggestion.Sender }
+
+// pendingKeepSuggestionCount returns the number of keep suggestions for the account
+func pendingKeepSuggestionCount(account string) int {
+	if account == "" {
+		return 0
+	}
+	store, err := config.LoadKeepSuggestions()
+	if err != nil {
+		return 0
+	}
+	return len(store.PendingFor(account))
+}
+
+func (m appModel) initKeepSuggestions() (tea.Model, tea.Cmd) {
+	store, err := config.LoadKeepSuggestions()
+	if err != nil {
+		m.errMsg = "Failed to load keep suggestions: " + err.Error()
+		return m, nil
+	}
+
+	var items []list.Item
+	for _, s := range store.PendingFor(m.savedEmail) {
+		items = append(items, keepSuggestionItem{suggestion: s})
+	}
+
+	l := newList(items, "💡  Senders You Engage With", false)
+
+	h, v := docStyle.GetFrameSize()
+	if m.width > 0 && m.height > 0 {
+		l.SetSize(m.width-h, m.height-v-m.listChrome()+2)
+	}
+
+	m.keepSuggestionList = l
+	m.keepSuggestionMsg = ""
+	m.screen = screenKeepSuggestions
+	return m, nil
+}
+
+func (m appModel) updateKeepSuggestions(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			m.screen = screenWelcome
+			m.refreshKeepSuggestionsMenuItem()
+			return m, nil
+		case "a":
+			// Accept: add the sender to the keep list
+			i, ok := m.keepSuggestionList.SelectedItem().(keepSuggestionItem)
+			if !ok {
+				return m, nil
+			}
+			settings, err := config.LoadSettings()
+			if err != nil {
+				m.keepSuggestionMsg = "❌ Failed to load settings: " + err.Error()
+				return m, nil
+			}
+			if !containsSender(settings.KeepList, i.suggestion.Sender) {
+				if _, err := config.ToggleKeep(i.suggestion.Sender); err != nil {
+					m.keepSuggestionMsg = "❌ Failed to update keep list: " + err.Error()
+					return m, nil
+				}
+			}
+			if settings, err := config.LoadSettings(); err == nil {
+				m.keepList = settings.KeepList
+			}
+			m.removeKeepSuggestion(i.suggestion.Sender, false)
+			logAction("Added %s to the keep list", i.suggestion.Sender)
+			m.keepSuggestionMsg = "🛡️  Keeping " + i.suggestion.Sender
+			return m, nil
+		case "d":
+			// Dismiss: don't suggest this sender again
+			i, ok := m.keepSuggestionList.SelectedItem().(keepSuggestionItem)
+			if !ok {
+				return m, nil
+			}
+			m.removeKeepSuggestion(i.suggestion.Sender, true)
+			m.keepSuggestionMsg = "Won't suggest " + i.suggestion.Sender + " again"
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.keepSuggestionList, cmd = m.keepSuggestionList.Update(msg)
+	return m, cmd
+}
+
+func containsSender(senders []string, sender string) bool {
+	for _, s := range senders {
+		if strings.EqualFold(s, sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeKeepSuggestion resolves sender and drops it from the list
+func (m *appModel) removeKeepSuggestion(sender string, dismissed bool) {
+	if err := config.ResolveKeepSuggestion(m.savedEmail, sender, dismissed); err != nil {
+		m.keepSuggestionMsg = "❌ Failed to update suggestions: " + err.Error()
+		return
+	}
+	var items []list.Item
+	for _, item := range m.keepSuggestionList.Items() {
+		if item, ok := item.(keepSuggestionItem); ok && item.suggestion.Sender == sender {
+			continue
+		}
+		items = append(items, item)
+	}
+	m.keepSuggestionList.SetItems(items)
+}
+
+// refreshKeepSuggestionsMenuItem adds, updates or removes the welcome menu
+// entry for keep suggestions
+func (m *appModel) refreshKeepSuggestionsMenuItem() {
+	count := pendingKeepSuggestionCount(m.savedEmail)
+	items := m.welcomeList.Items()
+	insertAt := -1
+	for idx, item := range items {
+		item, ok := item.(appMenuItem)
+		if !ok {
+			continue
+		}
+		switch item.action {
+		case screenKeepSuggestions:
+			if count == 0 {
+				m.welcomeList.RemoveItem(idx)
+			} else {
+				item.title = fmt.Sprintf("💡 Keep suggestions (%d)", count)
+				m.welcomeList.SetItem(idx, item)
+			}
+			return
+		case screenAnalyzeInput, screenReview:
+			insertAt = idx + 1
+		}
+	}
+	if count > 0 && insertAt != -1 {
+		m.welcomeList.InsertItem(insertAt, keepSuggestionsMenuItem(count))
+	}
+}
+
+func keepSuggestionsMenuItem(count int) appMenuItem {
+	return appMenuItem{
+		title:       fmt.Sprintf("💡 Keep suggestions (%d)", count),
+		description: "Senders you read often that aren't on your keep list",
+		action:      screenKeepSuggestions,
+	}
+}
+
+func (m appModel) viewKeepSuggestions() string {
+	listView := docStyle.Render(m.keepSuggestionList.View())
+	if len(m.keepSuggestionList.Items()) == 0 {
+		listView = docStyle.Render(emptyStateStyle.Render("✨\n\nNo suggestions\n\nYour keep list is up to date."))
+	}
+
+	status := ""
+	if m.keepSuggestionMsg != "" {
+		status = "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Padding(0, 1).Render(m.keepSuggestionMsg)
+	}
+
+	help := helpStyle.Render("[↑↓] Navigate  [a] Add to keep list  [d] Dismiss  [Esc] Back")
+	return listView + status + "\n" + help
+}
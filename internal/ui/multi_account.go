@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+)
+
+// accountAnalysis is the analysis result of one account
+type accountAnalysis struct {
+	email    string
+	password string
+	server   string
+	stats    []imap.NewsletterStat
+	folders  []imap.FolderStat
+}
+
+// analyzeAllAccounts analyzes every saved account concurrently. Accounts
+// that fail are left out unless all of them do.
+func analyzeAllAccounts(since time.Time, folders []string) tea.Msg {
+	accounts, err := config.GetAllAccounts()
+	if err != nil {
+		return errorMsg("Failed to load accounts: " + err.Error())
+	}
+
+	results := make([]accountAnalysis, len(accounts))
+	errs := make([]error, len(accounts))
+	var wg sync.WaitGroup
+	for idx, account := range accounts {
+		wg.Add(1)
+		go func(idx int, account config.Account) {
+			defer wg.Done()
+			password, err := config.Decrypt(account.Password)
+			if err != nil {
+				errs[idx] = fmt.Errorf("%s: failed to decrypt password: %w", account.Email, err)
+				return
+			}
+			stats, folderStats, err := imap.FetchFolderStats(account.Server, account.Email, password, since, folders)
+			if err != nil {
+				errs[idx] = fmt.Errorf("%s: %w", account.Email, err)
+				return
+			}
+			results[idx] = accountAnalysis{
+				email:    account.Email,
+				password: password,
+				server:   account.Server,
+				stats:    stats,
+				folders:  folderStats,
+			}
+		}(idx, account)
+	}
+	wg.Wait()
+
+	var analyzed []accountAnalysis
+	var failures []string
+	for idx := range accounts {
+		if errs[idx] != nil {
+			failures = append(failures, errs[idx].Error())
+			continue
+		}
+		analyzed = append(analyzed, results[idx])
+		recordHistory(results[idx].email, since, results[idx].stats)
+		suggestKeeps(results[idx].email, results[idx].stats)
+	}
+	if len(analyzed) == 0 {
+		return errorMsg("Failed to fetch newsletters: " + strings.Join(failures, "; "))
+	}
+
+	stats, folderStats := mergeAccountAnalyses(analyzed)
+	return analysisCompleteMsg{stats: stats, folders: folderStats, since: since, accounts: analyzed}
+}
+
+// mergeAccountAnalyses adds up the results of several accounts per sender.
+// Folders are listed per account.
+func mergeAccountAnalyses(analyzed []accountAnalysis) ([]imap.NewsletterStat, []imap.FolderStat) {
+	merged := make(map[string]*imap.NewsletterStat)
+	transactional := make(map[string]int) // Emails from accounts where the sender looked transactional
+	var order []string
+	var folders []imap.FolderStat
+
+	for _, a := range analyzed {
+		for _, s := range a.stats {
+			key := s.Sender.Key()
+			m, ok := merged[key]
+			if !ok {
+				m = &imap.NewsletterStat{Sender: s.Sender}
+				merged[key] = m
+				order = append(order, key)
+			}
+			m.Count += s.Count
+			if m.Sender.Name == "" {
+				m.Sender.Name = s.Sender.Name
+			}
+			if m.Unsubscribe == "" {
+				m.Unsubscribe = s.Unsubscribe
+			}
+			if m.Preferences == "" {
+				m.Preferences = s.Preferences
+			}
+			if s.Transactional {
+				transactional[key] += s.Count
+			}
+			m.ScannedEmails += s.ScannedEmails
+			m.TrackedEmails += s.TrackedEmails
+			m.ReadEmails += s.ReadEmails
+			m.RepliedEmails += s.RepliedEmails
+			m.StarredEmails += s.StarredEmails
+			for h, n := range s.ByHour {
+				m.ByHour[h] += n
+			}
+			for d, n := range s.ByWeekday {
+				m.ByWeekday[d] += n
+			}
+		}
+		for _, f := range a.folders {
+			f.Name = a.email + ": " + f.Name
+			folders = append(folders, f)
+		}
+	}
+
+	stats := make([]imap.NewsletterStat, 0, len(order))
+	for _, sender := range order {
+		s := *merged[sender]
+		s.Transactional = transactional[sender]*2 >= s.Count
+		stats = append(stats, s)
+	}
+	return stats, folders
+}
+
+// inAggregateView reports whether the dashboard shows the combined results
+// of several accounts
+func (m appModel) inAggregateView() bool {
+	return len(m.dashboardAccounts) > 0 && m.dashboardAccount == 0
+}
+
+// dashboardEmail returns the account the dashboard shows, or an empty string
+// for the aggregate of several accounts
+func (m appModel) dashboardEmail() string {
+	if len(m.dashboardAccounts) == 0 {
+		return m.savedEmail
+	}
+	if m.dashboardAccount == 0 {
+		return ""
+	}
+	return m.dashboardAccounts[m.dashboardAccount-1].email
+}
+
+// dashboardCredentials returns the credentials of the account the dashboard
+// shows, used to send mailto: unsubscribes from the right address
+func (m appModel) dashboardCredentials() (email, password, server string) {
+	if len(m.dashboardAccounts) == 0 || m.dashboardAccount == 0 {
+		return m.savedEmail, m.savedPassword, m.savedServer
+	}
+	a := m.dashboardAccounts[m.dashboardAccount-1]
+	return a.email, a.password, a.server
+}
+
+// dashboardAccountLabel names the shown result set of a multi-account analysis
+func (m appModel) dashboardAccountLabel() string {
+	if len(m.dashboardAccounts) == 0 {
+		return ""
+	}
+	if m.dashboardAccount == 0 {
+		return fmt.Sprintf("👥 All %d accounts", len(m.dashboardAccounts))
+	}
+	return fmt.Sprintf("📧 %s (%d/%d)", m.dashboardEmail(), m.dashboardAccount, len(m.dashboardAccounts))
+}
+
+// cycleDashboardAccount switches to the next account's results, after the
+// last one back to the aggregate. Each account keeps its own selection, and
+// results already categorized are shown again without categorizing them anew.
+func (m appModel) cycleDashboardAccount() (tea.Model, tea.Cmd) {
+	if m.accountSelections == nil {
+		m.accountSelections = make(map[int]map[string]bool)
+	}
+	m.accountSelections[m.dashboardAccount] = m.dashboardSelected
+	if !m.categorizing() {
+		if m.accountItems == nil {
+			m.accountItems = make(map[int][]list.Item)
+		}
+		m.accountItems[m.dashboardAccount] = m.dashboardItems
+	}
+
+	m.dashboardAccount = (m.dashboardAccount + 1) % (len(m.dashboardAccounts) + 1)
+	m.dashboardSelected = m.accountSelections[m.dashboardAccount]
+	if m.dashboardSelected == nil {
+		m.dashboardSelected = make(map[string]bool)
+	}
+
+	var stats []imap.NewsletterStat
+	var folders []imap.FolderStat
+	if m.dashboardAccount == 0 {
+		stats, folders = mergeAccountAnalyses(m.dashboardAccounts)
+	} else {
+		a := m.dashboardAccounts[m.dashboardAccount-1]
+		stats, folders = a.stats, a.folders
+	}
+
+	var cmd tea.Cmd
+	if items, ok := m.accountItems[m.dashboardAccount]; ok {
+		m.restoreDashboard(items, stats, folders)
+	} else {
+		cmd = m.buildDashboard(stats, folders)
+	}
+	m.dashboardMsg = ""
+	return m, cmd
+}
+
+// categorizing reports whether dashboard items are still waiting for the
+// categorizer
+func (m appModel) categorizing() bool {
+	for _, item := range m.dashboardItems {
+		if item, ok := item.(dashboardListItem); ok && item.categorizing {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreDashboard shows items cached by cycleDashboardAccount, bringing
+// the selection, unsubscribes and keep list up to date
+func (m *appModel) restoreDashboard(items []list.Item, stats []imap.NewsletterStat, folders []imap.FolderStat) {
+	m.dashboardUnsubscribed, _ = config.GetUnsubscribedList()
+	if m.dashboardUnsubscribed == nil {
+		m.dashboardUnsubscribed = make(map[string]bool)
+	}
+
+	totalEmails := 0
+	restored := make([]list.Item, len(items))
+	for idx, item := range items {
+		if i, ok := item.(dashboardListItem); ok {
+			i.selected = m.dashboardSelected[i.title]
+			i.unsubscribed = m.dashboardUnsubscribed[i.title]
+			totalEmails += i.count
+			item = i
+		}
+		restored[idx] = item
+	}
+
+	m.dashboardItems = restored
+	m.dashboardStats = stats
+	m.dashboardFolders = folders
+	m.totalEmails = totalEmails
+	m.totalNewsletters = len(stats)
+	// Categories still arriving for the account left behind are dropped
+	m.enrichSeq++
+	m.refreshKeptItems()
+	m.dashboardList.ResetSelected()
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/watch"
+)
+
+// noiseAlert describes this week's newsletter volume when the watch daemon
+// measured it above the user's noise budget, empty otherwise
+func noiseAlert() string {
+	settings, err := config.LoadSettings()
+	if err != nil || settings.NoiseBudget <= 0 {
+		return ""
+	}
+	store, err := config.LoadNoise()
+	if err != nil {
+		return ""
+	}
+	week := store.Week(time.Now())
+	if week == nil || week.Total() <= settings.NoiseBudget {
+		return ""
+	}
+	return watch.NoiseSummary(week, settings)
+}
+
+// viewNoiseAlert renders the noise budget banner of the welcome screen
+func (m appModel) viewNoiseAlert() string {
+	if m.noiseAlert == "" {
+		return ""
+	}
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("208")).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("208")).
+		Padding(0, 1).
+		MarginTop(1)
+	if m.width > 10 {
+		style = style.Width(m.width - 8)
+	}
+	return "\n" + style.Render("🔊 Noise budget exceeded\n   "+m.noiseAlert)
+}
@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// maxPaletteResults is how many matching actions the palette shows at once
+const maxPaletteResults = 10
+
+// paletteAction is an entry of the command palette
+type paletteAction struct {
+	title string
+	key   string // Keybinding that does the same, shown as a hint
+	// available reports whether the action makes sense right now
+	available func(m appModel) bool
+	run       func(m appModel) (tea.Model, tea.Cmd)
+}
+
+// pressKey replays a keybinding, so palette actions behave exactly like the
+// key they stand for
+func pressKey(key string) func(m appModel) (tea.Model, tea.Cmd) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == "ctrl+s" {
+		msg = tea.KeyMsg{Type: tea.KeyCtrlS}
+	}
+	return func(m appModel) (tea.Model, tea.Cmd) {
+		return m.Update(msg)
+	}
+}
+
+func openScreenAction(s screen) func(m appModel) (tea.Model, tea.Cmd) {
+	return func(m appModel) (tea.Model, tea.Cmd) {
+		return m.openScreen(s)
+	}
+}
+
+func loggedIn(m appModel) bool {
+	return m.savedEmail != "" && m.savedPassword != "" && m.savedServer != ""
+}
+
+func premium(m appModel) bool { return m.premiumEnabled }
+
+func onDashboard(m appModel) bool { return m.screen == screenDashboard && !m.unsubscribing }
+
+// paletteActions lists every action of the palette, available or not
+func paletteActions() []paletteAction {
+	return []paletteAction{
+		{title: "Analyze newsletters", available: loggedIn, run: openScreenAction(screenAnalyzeInput)},
+		{title: "Switch account", available: func(appModel) bool { return true }, run: openScreenAction(screenAccounts)},
+		{title: "Add account", available: func(appModel) bool { return true }, run: openScreenAction(screenLogin)},
+		{title: "Review newsletters found while away", available: func(m appModel) bool { return pendingReviewCount(m.savedEmail) > 0 }, run: openScreenAction(screenReview)},
+		{title: "Keep suggestions", available: func(m appModel) bool { return pendingKeepSuggestionCount(m.savedEmail) > 0 }, run: openScreenAction(screenKeepSuggestions)},
+		{title: "Premium", available: func(appModel) bool { return true }, run: openScreenAction(screenPremium)},
+		{title: "Sync now", key: "ctrl+s", available: premium, run: pressKey("ctrl+s")},
+		{title: "Open sync settings", available: premium, run: func(m appModel) (tea.Model, tea.Cmd) {
+			m.screen = screenSyncSettings
+			return m, nil
+		}},
+		{title: "Export report (CSV)", available: onDashboard, run: func(m appModel) (tea.Model, tea.Cmd) {
+			return m.exportReport()
+		}},
+		{title: "Unsubscribe from selected", key: "U", available: func(m appModel) bool { return onDashboard(m) && len(m.dashboardSelected) > 0 }, run: pressKey("U")},
+		{title: "Next account", key: "a", available: func(m appModel) bool { return onDashboard(m) && len(m.dashboardAccounts) > 0 }, run: pressKey("a")},
+		{title: "Toggle folder heatmap", key: "f", available: onDashboard, run: pressKey("f")},
+		{title: "Toggle arrival times", key: "t", available: onDashboard, run: pressKey("t")},
+		{title: "Switch names / addresses", key: "n", available: onDashboard, run: pressKey("n")},
+		{title: "Share snapshot with web dashboard", key: "S", available: func(m appModel) bool { return onDashboard(m) && m.premiumEnabled }, run: pressKey("S")},
+		{title: "Show tips", key: "?", available: onDashboard, run: pressKey("?")},
+		{title: "Quit", available: func(appModel) bool { return true }, run: func(m appModel) (tea.Model, tea.Cmd) {
+			if m.premiumEnabled {
+				m.screen = screenQuitConfirm
+				return m, nil
+			}
+			return m, tea.Quit
+		}},
+	}
+}
+
+// paletteMatch is an available action matching the palette query
+type paletteMatch struct {
+	action  paletteAction
+	matched []int // Byte offsets of the title characters that matched, for highlighting
+}
+
+func (m appModel) openPalette() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = "Type a command..."
+	input.Prompt = "› "
+	input.Focus()
+
+	m.paletteInput = input
+	m.paletteIndex = 0
+	m.showPalette = true
+	return m, textinput.Blink
+}
+
+// paletteMatches returns the available actions, fuzzy matched against the
+// query and best matches first
+func (m appModel) paletteMatches() []paletteMatch {
+	var available []paletteAction
+	for _, action := range paletteActions() {
+		if action.available(m) {
+			available = append(available, action)
+		}
+	}
+
+	query := strings.TrimSpace(m.paletteInput.Value())
+	if query == "" {
+		matches := make([]paletteMatch, 0, len(available))
+		for _, action := range available {
+			matches = append(matches, paletteMatch{action: action})
+		}
+		return matches
+	}
+
+	titles := make([]string, len(available))
+	for i, action := range available {
+		titles[i] = action.title
+	}
+	var matches []paletteMatch
+	for _, found := range fuzzy.Find(query, titles) {
+		matches = append(matches, paletteMatch{action: available[found.Index], matched: found.MatchedIndexes})
+	}
+	return matches
+}
+
+func (m appModel) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.paletteMatches()
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "ctrl+p":
+		m.showPalette = false
+		return m, nil
+	case "up", "ctrl+k":
+		if m.paletteIndex > 0 {
+			m.paletteIndex--
+		}
+		return m, nil
+	case "down", "ctrl+j":
+		if m.paletteIndex < len(matches)-1 && m.paletteIndex < maxPaletteResults-1 {
+			m.paletteIndex++
+		}
+		return m, nil
+	case "enter":
+		m.showPalette = false
+		if m.paletteIndex >= len(matches) {
+			return m, nil
+		}
+		m.errMsg = ""
+		return matches[m.paletteIndex].action.run(m)
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.paletteIndex = 0 // The matches changed
+	return m, cmd
+}
+
+func (m appModel) viewPalette() string {
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	matchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("86")).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("⌘  Command Palette") + "\n\n")
+	b.WriteString(m.paletteInput.View() + "\n\n")
+
+	matches := m.paletteMatches()
+	if len(matches) == 0 {
+		b.WriteString(keyStyle.Render("No matching commands"))
+	}
+	for idx, match := range matches {
+		if idx == maxPaletteResults {
+			break
+		}
+		title := highlightRunes(match.action.title, match.matched, matchStyle)
+		cursor := "  "
+		if idx == m.paletteIndex {
+			cursor = selectedStyle.Render("▸ ")
+			title = selectedStyle.Render(match.action.title)
+		}
+		line := cursor + title
+		if match.action.key != "" {
+			line += "  " + keyStyle.Render("["+match.action.key+"]")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(1, 2).
+		Width(60).
+		Render(strings.TrimRight(b.String(), "\n"))
+	help := helpStyle.Render("[↑↓] Navigate  [Enter] Run  [Esc] Close")
+	return docStyle.Render(box) + "\n" + help
+}
+
+// highlightRunes renders the characters of s at the given byte offsets with style
+func highlightRunes(s string, indexes []int, style lipgloss.Style) string {
+	if len(indexes) == 0 {
+		return s
+	}
+	matched := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		matched[i] = true
+	}
+	var b strings.Builder
+	for i, r := range s { // Indexes are byte offsets
+		if matched[i] {
+			b.WriteString(style.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// openPreferenceCenter opens the highlighted sender's preference center and
+// asks whether the frequency was reduced there. Senders already marked are
+// unmarked instead.
+func (m appModel) openPreferenceCenter() (tea.Model, tea.Cmd) {
+	i, ok := m.dashboardList.SelectedItem().(dashboardListItem)
+	if !ok {
+		return m, nil
+	}
+	if i.frequencyReduced {
+		if err := config.SetFrequencyReduced(i.title, false); err != nil {
+			m.dashboardMsg = "❌  Failed to save: " + err.Error()
+			return m, nil
+		}
+		m.setFrequencyReduced(i.title, false)
+		logAction("Cleared reduced frequency for %s", i.title)
+		m.dashboardMsg = "Cleared reduced frequency for " + i.title
+		return m, nil
+	}
+	if i.preferences == "" {
+		m.dashboardMsg = "⚠️  No preference center found for " + i.title
+		return m, nil
+	}
+
+	if err := openBrowser(i.preferences); err != nil {
+		m.dashboardMsg = "❌  Failed to open browser: " + err.Error() + " | Link: " + hyperlink(i.preferences, i.preferences)
+		return m, nil
+	}
+	m.pendingConfirm = "frequency"
+	m.pendingSender = i.title
+	m.dashboardMsg = "⚙️  Opened the preference center of " + i.title + ". Did you reduce the frequency? [y/N]"
+	return m, nil
+}
+
+// confirmFrequencyReduced marks the sender whose preference center was
+// opened as frequency reduced
+func (m appModel) confirmFrequencyReduced(confirmed bool) (tea.Model, tea.Cmd) {
+	sender := m.pendingSender
+	m.pendingSender = ""
+	if !confirmed {
+		m.dashboardMsg = ""
+		return m, nil
+	}
+	if err := config.SetFrequencyReduced(sender, true); err != nil {
+		m.dashboardMsg = "❌  Failed to save: " + err.Error()
+		return m, nil
+	}
+	m.setFrequencyReduced(sender, true)
+	logAction("Reduced the frequency of %s in its preference center", sender)
+	m.dashboardMsg = "🔉 Marked " + sender + " as frequency reduced"
+	return m, nil
+}
+
+// setFrequencyReduced updates the dashboard item of sender
+func (m *appModel) setFrequencyReduced(sender string, reduced bool) {
+	m.setDashboardItem(sender, func(i *dashboardListItem) {
+		i.frequencyReduced = reduced
+	})
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -26,10 +27,18 @@ func (m appModel) updatePremium(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.screen = screenWelcome
 			m.premiumMsg = ""
 			return m, nil
+		case "pgdown", "pgup":
+			pages := m.premiumPages()
+			if msg.String() == "pgdown" {
+				m.premiumPage = min(m.premiumPage+1, pages-1)
+			} else {
+				m.premiumPage = max(m.premiumPage-1, 0)
+			}
+			return m, nil
 		case "r":
 			if m.premiumEnabled {
 				// Refresh license features and subscription status
-				return m, tea.Batch(m.fetchLicenseFeatures(), m.fetchSubscriptionStatus())
+				return m, tea.Batch(m.fetchLicenseFeatures(context.Background()), m.fetchSubscriptionStatus(context.Background()))
 			}
 		case "tab", "shift+tab", "enter", "up", "down":
 			// Handle tab/enter navigation
@@ -84,6 +93,21 @@ func (m appModel) updatePremium(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.premiumSyncing = true
 				return m, m.syncFromCloud()
 			}
+		case "z":
+			if m.premiumEnabled {
+				// Undo the last pull, restoring the local state from before it
+				snap, err := api.UndoLastPull()
+				if err != nil {
+					m.premiumMsg = "❌ Failed to undo pull: " + err.Error()
+					return m, nil
+				}
+				if accounts, err := config.GetAllAccounts(); err == nil {
+					m.accounts = accounts
+				}
+				m.dashboardUnsubscribed, _ = config.GetUnsubscribedList()
+				m.premiumMsg = "✅ Restored local data from before the pull of " + snap.TakenAt.Format("Jan 2, 15:04")
+				return m, nil
+			}
 		case "o", "0":
 			if m.premiumEnabled {
 				m.screen = screenSyncSettings
@@ -92,6 +116,10 @@ func (m appModel) updatePremium(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "d":
 			if m.premiumEnabled {
 				m.screen = screenDeleteConfirm
+				if !config.ShouldConfirm(true) {
+					m.deleteConfirmDeleting = true
+					return m, m.deleteAccountFromCloud()
+				}
 				return m, nil
 			}
 		case "u":
@@ -102,6 +130,11 @@ func (m appModel) updatePremium(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.screen = screenSubscription
 				return m, m.initSubscription()
 			}
+		case "t":
+			if m.premiumEnabled && m.premiumTier == "enterprise" {
+				// Manage team seats
+				return m.initTeam()
+			}
 		case "m":
 			if m.premiumEnabled {
 				// Manage subscription - open Stripe Customer Portal
@@ -119,7 +152,7 @@ func (m appModel) updatePremium(msg tea.Msg) (tea.Model, tea.Cmd) {
 				dashboardURL := api.GetDashboardURL()
 				if dashboardURL != "" {
 					if err := openBrowser(dashboardURL); err != nil {
-						m.premiumMsg = "❌ Failed to open dashboard: " + err.Error()
+						m.premiumMsg = "❌ Failed to open dashboard: " + err.Error() + "\n   Open it manually: " + hyperlink(dashboardURL, dashboardURL)
 					} else {
 						m.premiumMsg = "✅ Opening dashboard in browser..."
 					}
@@ -140,8 +173,13 @@ func (m appModel) updatePremium(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.premiumEnabled = true
 			m.premiumEmail = strings.TrimSpace(m.premiumInputs[1].Value())
 			m.premiumAPIURL = strings.TrimSpace(m.premiumInputs[0].Value())
-			// Fetch license features asynchronously (non-blocking)
-			return m, m.fetchLicenseFeatures()
+			if m.sessionExpired {
+				// Push what was queued while the session was expired
+				m.sessionExpired = false
+				return m, tea.Batch(m.fetchLicenseFeatures(context.Background()), m.fetchSubscriptionStatus(context.Background()), m.periodicSync())
+			}
+			// Fetch license features and subscription status asynchronously (non-blocking)
+			return m, tea.Batch(m.fetchLicenseFeatures(context.Background()), m.fetchSubscriptionStatus(context.Background()))
 		} else {
 			m.premiumMsg = "❌ " + msg.message
 		}
@@ -165,26 +203,13 @@ func (m appModel) updatePremium(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.analyzingSpinner, cmd = m.analyzingSpinner.Update(msg)
 			return m, cmd
 		}
-	case licenseFeaturesMsg:
-		if msg.err == nil {
-			m.premiumTier = msg.tier
-			m.premiumFeatures = msg.features
-		}
-		// Also fetch subscription status
-		return m, m.fetchSubscriptionStatus()
-	case subscriptionStatusMsg:
-		m.currentSubscription = msg.subscription
-		if msg.err != nil {
-			// Silently ignore errors - user might not have subscription yet
-		}
-		return m, nil
 	case subscriptionPortalMsg:
 		if msg.err != nil {
 			m.premiumMsg = "❌ Failed to open subscription portal: " + msg.err.Error()
 		} else if msg.url != "" {
 			// Open browser
 			if err := openBrowser(msg.url); err != nil {
-				m.premiumMsg = "❌ Failed to open browser: " + err.Error()
+				m.premiumMsg = "❌ Failed to open browser: " + err.Error() + "\n   Open it manually: " + hyperlink(msg.url, msg.url)
 			} else {
 				m.premiumMsg = "✅ Opening subscription management in browser..."
 			}
@@ -285,6 +310,15 @@ func (m appModel) submitPremiumLogin() tea.Cmd {
 			AnalyticsExplicitlySet: false, // Not explicitly set yet (default)
 		}
 
+		// Logging in again after the session expired keeps the sync settings
+		if existing, err := api.GetPremiumConfig(); err == nil && existing.SessionExpired &&
+			existing.Email == email && existing.APIURL == apiURL {
+			existing.Token = authResp.Token
+			existing.RefreshToken = authResp.RefreshToken
+			existing.SessionExpired = false
+			premiumConfig = existing
+		}
+
 		if err := api.SavePremiumConfig(premiumConfig); err != nil {
 			return premiumLoginMsg{
 				success: false,
@@ -302,9 +336,9 @@ func (m appModel) submitPremiumLogin() tea.Cmd {
 	}
 }
 
-func (m appModel) fetchLicenseFeatures() tea.Cmd {
+func (m appModel) fetchLicenseFeatures(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
-		features, err := api.GetLicenseFeatures()
+		features, err := api.GetLicenseFeaturesContext(ctx)
 		if err != nil {
 			// Return error but don't block - just use defaults
 			return licenseFeaturesMsg{
@@ -335,7 +369,7 @@ func (m appModel) fetchLicenseFeatures() tea.Cmd {
 	}
 }
 
-func (m appModel) fetchSubscriptionStatus() tea.Cmd {
+func (m appModel) fetchSubscriptionStatus(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		client, err := api.GetAPIClient()
 		if err != nil {
@@ -345,7 +379,7 @@ func (m appModel) fetchSubscriptionStatus() tea.Cmd {
 			}
 		}
 
-		subscription, err := client.GetCurrentSubscription()
+		subscription, err := client.WithContext(ctx).GetCurrentSubscription()
 		if err != nil {
 			// No subscription is okay - user might not have one yet
 			return subscriptionStatusMsg{
@@ -516,6 +550,9 @@ func (m appModel) syncFromCloud() tea.Cmd {
 			}
 		}
 
+		// Keep the local state so the pull can be undone
+		snapshot := api.NewPullSnapshot()
+
 		// Get accounts from cloud
 		cloudAccounts, err := api.SyncAccountsFromCloud()
 		if err != nil {
@@ -535,6 +572,10 @@ func (m appModel) syncFromCloud() tea.Cmd {
 
 		// Get unsubscribed from cloud
 		cloudUnsubscribed, err := api.SyncUnsubscribedFromCloud()
+		if pc, pcErr := api.GetPremiumConfig(); pcErr == nil {
+			snapshot.AccountsVersion = pc.LocalAccountsVersion
+			snapshot.UnsubscribedVersion = pc.LocalUnsubscribedVersion
+		}
 		if err != nil {
 			// Check if error is subscription-related
 			if strings.Contains(err.Error(), "subscription") || strings.Contains(err.Error(), "403") || strings.Contains(err.Error(), "Forbidden") {
@@ -560,19 +601,20 @@ func (m appModel) syncFromCloud() tea.Cmd {
 			// Create map of local senders
 			localSenders := make(map[string]bool)
 			for _, n := range localStore.Newsletters {
-				localSenders[n.Sender] = true
+				localSenders[config.SenderKey(n.Sender)] = true
 			}
 
 			// Add cloud newsletters that don't exist locally
 			updated := false
 			for _, cloudNewsletter := range cloudUnsubscribed.Newsletters {
-				if !localSenders[cloudNewsletter.Sender] {
+				if !localSenders[config.SenderKey(cloudNewsletter.Sender)] {
 					localStore.Newsletters = append(localStore.Newsletters, cloudNewsletter)
 					updated = true
 				}
 			}
 
 			if updated {
+				snapshot.Save()
 				config.SaveUnsubscribed(localStore)
 			}
 		}
@@ -602,6 +644,7 @@ func (m appModel) syncFromCloud() tea.Cmd {
 		}
 
 		if added > 0 {
+			snapshot.Save()
 			if err := config.Save(*cfg); err != nil {
 				return premiumSyncMsg{
 					success: false,
@@ -633,6 +676,42 @@ func (m appModel) viewPremium() string {
 		)
 	}
 
+	content := m.premiumContent()
+	help := helpStyle.Render(m.premiumHelp())
+
+	// Long status and action lists are paged on short terminals
+	if m.compact() {
+		_, v := docStyle.GetFrameSize()
+		page, pages := paginate(collapseBlankLines(content), m.premiumPage, m.height-v-1)
+		if pages > 1 {
+			help = pageIndicator(m.premiumPage, pages) + "  " + help
+		}
+		return docStyle.Render(page + "\n" + help)
+	}
+
+	return docStyle.Render(content + "\n\n" + help)
+}
+
+// premiumPages returns the number of pages the premium screen is split into
+func (m appModel) premiumPages() int {
+	if !m.compact() {
+		return 1
+	}
+	_, v := docStyle.GetFrameSize()
+	_, pages := paginate(collapseBlankLines(m.premiumContent()), 0, m.height-v-1)
+	return pages
+}
+
+// premiumHelp returns the key help of the premium screen
+func (m appModel) premiumHelp() string {
+	if m.premiumEnabled {
+		return "[s] Sync  [p] Pull  [o] Settings  [Esc] Back"
+	}
+	return "[Tab] Next  [Enter] Login/Register  [Esc] Back"
+}
+
+// premiumContent renders the premium screen without its help line
+func (m appModel) premiumContent() string {
 	var content strings.Builder
 	content.WriteString(titleStyle.Render("☁️ Premium"))
 
@@ -798,6 +877,11 @@ func (m appModel) viewPremium() string {
 		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render("[p] Pull from Cloud"))
 		content.WriteString("\n")
 		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render("[o] Sync Settings"))
+		if snap, _ := api.LastPullSnapshot(); snap != nil {
+			content.WriteString("\n")
+			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render(
+				fmt.Sprintf("[z] Undo Last Pull (%s)", formatTimeAgo(snap.TakenAt))))
+		}
 
 		// Subscription actions
 		if m.currentSubscription != nil && m.currentSubscription.Status == "active" {
@@ -808,6 +892,11 @@ func (m appModel) viewPremium() string {
 			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render("[u] Subscribe / Upgrade"))
 		}
 
+		if m.premiumTier == "enterprise" {
+			content.WriteString("\n")
+			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Render("[t] Manage Team Seats"))
+		}
+
 		content.WriteString("\n")
 		content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("[d] Delete All Data (GDPR)"))
 
@@ -842,6 +931,14 @@ func (m appModel) viewPremium() string {
 			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("💡 View your API request statistics"))
 		}
 	} else {
+		// A local file, so reading it here doesn't block on the network
+		if license, _ := api.LoadOfflineLicense(time.Now()); license != nil {
+			content.WriteString("\n\n")
+			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Render(
+				fmt.Sprintf("🔑 Offline license: %s, valid until %s", strings.ToUpper(license.Tier[:1])+license.Tier[1:], license.ExpiresAt.Format("January 2, 2006"))))
+			content.WriteString("\n")
+			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("💡 Log in below only to use cloud sync"))
+		}
 		content.WriteString("\n\n")
 		content.WriteString("API URL:")
 		content.WriteString("\n")
@@ -861,15 +958,7 @@ func (m appModel) viewPremium() string {
 		content.WriteString(m.premiumMsg)
 	}
 
-	helpText := "[Tab] Next  [Enter] Login/Register  [Esc] Back"
-	if m.premiumEnabled {
-		helpText = "[s] Sync  [p] Pull  [o] Settings  [Esc] Back"
-	}
-	help := helpStyle.Render(helpText)
-	content.WriteString("\n\n")
-	content.WriteString(help)
-
-	return docStyle.Render(content.String())
+	return content.String()
 }
 
 // formatTimeAgo formats a time as "X ago" or relative time
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// premiumStartupTimeout bounds the auto-sync, license and subscription calls
+// made at startup, together
+const premiumStartupTimeout = 5 * time.Second
+
+// loadPremiumState runs the startup auto-sync and fetches the license and
+// the subscription concurrently, under one deadline. On a slow network the
+// pull waits for the next sync and the premium details are simply missing
+// until the premium screen fetches them again.
+func (m appModel) loadPremiumState(autoSync, details bool) tea.Cmd {
+	ctx, cancel := context.WithTimeout(context.Background(), premiumStartupTimeout)
+	var cmds []tea.Cmd
+	if autoSync {
+		cmds = append(cmds, m.checkAndSyncOnStartup(ctx))
+	}
+	if details {
+		cmds = append(cmds, m.fetchLicenseFeatures(ctx), m.fetchSubscriptionStatus(ctx))
+	}
+	if len(cmds) == 0 {
+		cancel()
+		return nil
+	}
+
+	var pending sync.WaitGroup
+	pending.Add(len(cmds))
+	go func() {
+		pending.Wait()
+		cancel()
+	}()
+
+	for i, cmd := range cmds {
+		cmds[i] = func() tea.Msg {
+			defer pending.Done()
+			return cmd()
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// Exports of the same day get a numbered suffix up to this many
+const maxReportsPerDay = 100
+
+// exportReport writes every dashboard newsletter to a CSV file in the home
+// directory
+func (m appModel) exportReport() (tea.Model, tea.Cmd) {
+	path, err := writeReport(m.dashboardItems)
+	if err != nil {
+		m.dashboardMsg = "❌  Failed to export report: " + err.Error()
+		return m, nil
+	}
+	m.dashboardMsg = "📄  Report saved to " + path
+	return m, nil
+}
+
+func writeReport(items []list.Item) (string, error) {
+	if err := config.CheckWritable(); err != nil {
+		return "", err
+	}
+	path, f, err := createReportFile(time.Now())
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"sender", "name", "emails", "category", "quality_score", "unsubscribe", "unsubscribed", "kept", "transactional", "tracking_heavy"})
+	for _, item := range items {
+		i, ok := item.(dashboardListItem)
+		if !ok {
+			continue
+		}
+		_ = w.Write([]string{
+			csvCell(i.title),
+			csvCell(i.sender.Name),
+			strconv.Itoa(i.count),
+			csvCell(i.category),
+			strconv.Itoa(i.qualityScore),
+			csvCell(i.link),
+			strconv.FormatBool(i.unsubscribed),
+			strconv.FormatBool(i.kept),
+			strconv.FormatBool(i.transactional),
+			strconv.FormatBool(i.trackingHeavy),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// createReportFile creates a new report file in the home directory. An
+// earlier report of the same day is never overwritten: the name gets a
+// suffix instead, as in newsletter-report-2006-01-02-2.csv.
+func createReportFile(now time.Time) (string, *os.File, error) {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return "", nil, err
+	}
+	base := "newsletter-report-" + now.Format("2006-01-02")
+	for n := 1; n <= maxReportsPerDay; n++ {
+		name := base + ".csv"
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d.csv", base, n)
+		}
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		return path, f, nil
+	}
+	return "", nil, fmt.Errorf("%d reports already exported today", maxReportsPerDay)
+}
+
+// csvCell keeps a cell from being run as a formula by spreadsheets. Sender
+// names and addresses come from From headers, which senders control.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/unsubscribe"
+)
+
+// reviewListItem is a newsletter found by the watch daemon awaiting review
+type reviewListItem struct {
+	review config.PendingReview
+}
+
+func (i reviewListItem) Title() string {
+	countStyle := lipgloss.NewStyle().Foreground(getCountColor(i.review.Count)).Bold(true)
+	return i.review.Sender + "  " + countStyle.Render(fmt.Sprintf("(%d)", i.review.Count))
+}
+
+func (i reviewListItem) Description() string {
+	desc := "First seen " + formatTimeAgoSync(i.review.DetectedAt)
+	if i.review.Unsubscribe == "" {
+		return desc + "  •  ⚠️  No unsubscribe link"
+	}
+	link := i.review.Unsubscribe
+	if len(link) > 40 {
+		link = link[:37] + "..."
+	}
+	return desc + "  •  🔗 " + link
+}
+
+func (i reviewListItem) FilterValue() string { return i.review.Sender }
+
+type reviewResultMsg struct {
+	sender string
+	result unsubscribe.UnsubscribeResult
+}
+
+// pendingReviewCount returns the number of daemon findings for the account
+func pendingReviewCount(account string) int {
+	if account == "" {
+		return 0
+	}
+	store, err := config.LoadReviewStore()
+	if err != nil {
+		return 0
+	}
+	return len(store.PendingFor(account))
+}
+
+func (m appModel) initReviewList() (tea.Model, tea.Cmd) {
+	store, err := config.LoadReviewStore()
+	if err != nil {
+		m.errMsg = "Failed to load review queue: " + err.Error()
+		return m, nil
+	}
+
+	var items []list.Item
+	for _, p := range store.PendingFor(m.savedEmail) {
+		items = append(items, reviewListItem{review: p})
+	}
+
+	l := newList(items, "📥  New Newsletters to Review", false)
+
+	h, v := docStyle.GetFrameSize()
+	if m.width > 0 && m.height > 0 {
+		l.SetSize(m.width-h, m.height-v-m.listChrome()+2)
+	}
+
+	m.reviewList = l
+	m.reviewMsg = ""
+	m.screen = screenReview
+	return m, nil
+}
+
+func (m appModel) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case reviewResultMsg:
+		if !msg.result.Success {
+			logAction("Failed to unsubscribe from %s: %s", msg.sender, msg.result.ErrorMsg)
+			m.reviewMsg = fmt.Sprintf("❌ Failed to unsubscribe from %s: %s", msg.sender, msg.result.ErrorMsg)
+			return m, nil
+		}
+		logAction("Unsubscribed from %s (%s)", msg.sender, msg.result.Method)
+		config.AddUnsubscribed(config.NewSenderIdentity("", msg.sender))
+		if m.dashboardUnsubscribed != nil {
+			m.dashboardUnsubscribed[msg.sender] = true
+		}
+		m.removeReviewItem(msg.sender)
+		m.reviewMsg = "✅ Unsubscribed from " + msg.sender
+		go func() {
+			_ = api.AutoSync() // Silently fail if premium not enabled
+		}()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc", "q":
+			m.screen = screenWelcome
+			m.refreshReviewMenuItem()
+			return m, nil
+		case "a":
+			// Accept: unsubscribe from the sender
+			i, ok := m.reviewList.SelectedItem().(reviewListItem)
+			if !ok {
+				return m, nil
+			}
+			if i.review.Unsubscribe == "" {
+				m.reviewMsg = "⚠️  No unsubscribe link for " + i.review.Sender + ". Press [d] to dismiss it."
+				return m, nil
+			}
+			m.reviewMsg = "🔄 Unsubscribing from " + i.review.Sender + "..."
+			return m, m.acceptReview(i.review)
+		case "d":
+			// Dismiss: keep the newsletter and stop flagging it
+			i, ok := m.reviewList.SelectedItem().(reviewListItem)
+			if !ok {
+				return m, nil
+			}
+			m.removeReviewItem(i.review.Sender)
+			m.reviewMsg = "👌 Keeping " + i.review.Sender
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.reviewList, cmd = m.reviewList.Update(msg)
+	return m, cmd
+}
+
+func (m appModel) acceptReview(review config.PendingReview) tea.Cmd {
+	return func() tea.Msg {
+		result := unsubscribe.Unsubscribe(review.Sender, review.Unsubscribe, m.savedEmail, m.savedPassword, m.savedServer)
+		return reviewResultMsg{sender: review.Sender, result: result}
+	}
+}
+
+// removeReviewItem resolves sender in the queue and drops it from the list
+func (m *appModel) removeReviewItem(sender string) {
+	if err := config.ResolvePending(m.savedEmail, sender); err != nil {
+		m.reviewMsg = "❌ Failed to update review queue: " + err.Error()
+		return
+	}
+	var items []list.Item
+	for _, item := range m.reviewList.Items() {
+		if item, ok := item.(reviewListItem); ok && item.review.Sender == sender {
+			continue
+		}
+		items = append(items, item)
+	}
+	m.reviewList.SetItems(items)
+}
+
+// refreshReviewMenuItem updates or removes the welcome menu entry for the review queue
+func (m *appModel) refreshReviewMenuItem() {
+	count := pendingReviewCount(m.savedEmail)
+	items := m.welcomeList.Items()
+	for idx, item := range items {
+		if item, ok := item.(appMenuItem); ok && item.action == screenReview {
+			if count == 0 {
+				m.welcomeList.RemoveItem(idx)
+			} else {
+				item.title = fmt.Sprintf("📥 Review (%d)", count)
+				m.welcomeList.SetItem(idx, item)
+			}
+			return
+		}
+	}
+}
+
+func (m appModel) viewReview() string {
+	listView := docStyle.Render(m.reviewList.View())
+	if len(m.reviewList.Items()) == 0 {
+		listView = docStyle.Render(emptyStateStyle.Render("✨\n\nAll caught up\n\nNo new newsletters to review."))
+	}
+
+	status := ""
+	if m.reviewMsg != "" {
+		status = "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Padding(0, 1).Render(m.reviewMsg)
+	}
+
+	help := helpStyle.Render("[↑↓] Navigate  [a] Accept (unsubscribe)  [d] Dismiss (keep)  [Esc] Back")
+	return listView + status + "\n" + help
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+var senderAddressStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+// senderLabels returns the primary and secondary label of a newsletter:
+// the display name first unless the user prefers addresses or the sender
+// has none
+func (i dashboardListItem) senderLabels() (primary, secondary string) {
+	if i.sender.Name == "" {
+		return i.title, ""
+	}
+	if i.addressFirst {
+		return i.title, i.sender.Name
+	}
+	return i.sender.Name, i.title
+}
+
+// toggleSenderDisplay switches between display-name-first and
+// address-first labels and remembers the choice
+func (m *appModel) toggleSenderDisplay() {
+	m.addressFirst = !m.addressFirst
+	if settings, err := config.LoadSettings(); err == nil {
+		settings.AddressFirst = m.addressFirst
+		_ = config.SaveSettings(settings) // Only a display preference
+	}
+
+	for idx, item := range m.dashboardItems {
+		if item, ok := item.(dashboardListItem); ok {
+			item.addressFirst = m.addressFirst
+			m.dashboardItems[idx] = item
+		}
+	}
+	cursor := m.dashboardList.Index()
+	m.applySmartView()
+	m.dashboardList.Select(cursor)
+}
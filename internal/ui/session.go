@@ -0,0 +1,45 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/api"
+)
+
+// sessionExpiredMsg is sent when a sync found the premium session expired
+type sessionExpiredMsg struct{}
+
+var sessionExpiredStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("214")).
+	Bold(true)
+
+// checkSession notices a premium session that expired during a sync.
+// Premium features stay off until the user logs in again.
+func (m *appModel) checkSession() {
+	if api.IsSessionExpired() {
+		m.sessionExpired = true
+		m.premiumEnabled = false
+		m.isSyncing = false
+		m.syncStatusMsg = ""
+	}
+}
+
+// openPremiumRelogin shows the premium login with the saved API URL and
+// email, ready for the password
+func (m appModel) openPremiumRelogin() (tea.Model, tea.Cmd) {
+	m.screen = screenPremium
+	m.premiumMsg = ""
+	m.premiumFocused = len(m.premiumInputs) - 1
+	for i := range m.premiumInputs {
+		m.premiumInputs[i].Blur()
+	}
+	return m, m.premiumInputs[m.premiumFocused].Focus()
+}
+
+// viewSessionExpired renders the re-login prompt, or nothing
+func (m appModel) viewSessionExpired() string {
+	if !m.sessionExpired {
+		return ""
+	}
+	return sessionExpiredStyle.Render("⚠️  Premium session expired — press L to log in again")
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+var (
+	viewTabStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			Padding(0, 1)
+
+	activeViewTabStyle = lipgloss.NewStyle().
+				Background(lipgloss.Color("63")).
+				Foreground(lipgloss.Color("230")).
+				Bold(true).
+				Padding(0, 1)
+)
+
+// applySmartView filters the dashboard list down to the items matching the
+// active smart view. View 0 shows everything.
+func (m *appModel) applySmartView() {
+	if m.activeView == 0 || m.activeView > len(m.smartViews) {
+		m.dashboardList.SetItems(m.dashboardItems)
+		m.dashboardList.ResetSelected()
+		return
+	}
+
+	view := m.smartViews[m.activeView-1]
+	var items []list.Item
+	for _, item := range m.dashboardItems {
+		if item, ok := item.(dashboardListItem); ok {
+			if view.Matches(m.dashboardEmail(), item.category, item.tags, item.count) {
+				items = append(items, item)
+			}
+		}
+	}
+	m.dashboardList.SetItems(items)
+	m.dashboardList.ResetSelected()
+}
+
+// refreshKeptItems marks items on the keep list or from trusted domains and
+// drops them from the current selection
+func (m *appModel) refreshKeptItems() {
+	for idx, item := range m.dashboardItems {
+		if item, ok := item.(dashboardListItem); ok {
+			item.kept = config.IsKept(item.sender, m.keepList, m.trustedDomains)
+			if item.kept {
+				delete(m.dashboardSelected, item.title)
+			}
+			m.dashboardItems[idx] = item
+		}
+	}
+	cursor := m.dashboardList.Index()
+	m.applySmartView()
+	m.dashboardList.Select(cursor)
+}
+
+// viewSmartViewBar renders the quick-switch bar above the dashboard
+func (m appModel) viewSmartViewBar() string {
+	if len(m.smartViews) == 0 {
+		return ""
+	}
+
+	names := []string{"All"}
+	for _, v := range m.smartViews {
+		names = append(names, v.Name)
+	}
+
+	var tabs []string
+	for i, name := range names {
+		if i == m.activeView {
+			tabs = append(tabs, activeViewTabStyle.Render(name))
+		} else {
+			tabs = append(tabs, viewTabStyle.Render(name))
+		}
+	}
+	return strings.Join(tabs, " ")
+}
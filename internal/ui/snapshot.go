@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+type snapshotUploadedMsg struct {
+	err error
+}
+
+// snapshotDetailDescriptions say what each detail level reveals
+var snapshotDetailDescriptions = map[string]string{
+	config.SnapshotTotals:  "totals per category",
+	config.SnapshotDomains: "counts per sender domain",
+	config.SnapshotSenders: "counts per sender address",
+}
+
+// shareSnapshot summarizes the dashboard and asks before uploading it, every
+// time, stating what would leave the machine
+func (m appModel) shareSnapshot() (tea.Model, tea.Cmd) {
+	if !m.premiumEnabled {
+		m.dashboardMsg = "☁️  Sharing with the web dashboard requires premium"
+		return m, nil
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		settings = &config.Settings{}
+	}
+	snap := api.NewAnalysisSnapshot(m.snapshotSenders(), m.dashboardSince, settings.SnapshotDetailLevel())
+
+	m.pendingSnapshot = snap
+	m.pendingConfirm = "snapshot"
+	m.dashboardMsg = fmt.Sprintf("☁️  Upload %d newsletters / %d emails (%s, no email contents) to the web dashboard? [y/N]",
+		snap.Newsletters, snap.Emails, snapshotDetailDescriptions[snap.Detail])
+	return m, nil
+}
+
+// confirmSnapshotUpload uploads the snapshot prepared by shareSnapshot
+func (m appModel) confirmSnapshotUpload(confirmed bool) (tea.Model, tea.Cmd) {
+	snap := m.pendingSnapshot
+	m.pendingSnapshot = nil
+	if !confirmed || snap == nil {
+		m.dashboardMsg = "Cancelled"
+		return m, nil
+	}
+
+	m.dashboardMsg = "☁️  Sharing snapshot..."
+	return m, func() tea.Msg {
+		return snapshotUploadedMsg{err: api.UploadAnalysisSnapshot(snap)}
+	}
+}
+
+// snapshotSenders converts every dashboard newsletter, not only the ones in
+// the active smart view
+func (m appModel) snapshotSenders() []api.SnapshotSender {
+	senders := make([]api.SnapshotSender, 0, len(m.dashboardItems))
+	for _, item := range m.dashboardItems {
+		i, ok := item.(dashboardListItem)
+		if !ok {
+			continue
+		}
+		senders = append(senders, api.SnapshotSender{
+			Sender:       i.title,
+			Count:        i.count,
+			Category:     i.category,
+			QualityScore: i.qualityScore,
+			Unsubscribed: i.unsubscribed,
+		})
+	}
+	return senders
+}
@@ -66,13 +66,6 @@ func (m *appModel) initSubscriptionList() {
 		planItem{id: "enterprise", name: "Enterprise", amount: 5000, interval: "month"},
 	}
 
-	delegate := list.NewDefaultDelegate()
-	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
-		Foreground(lipgloss.Color("229")).
-		Bold(true)
-	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.
-		Foreground(lipgloss.Color("219"))
-
 	// Use actual dimensions if available, otherwise default
 	width := 50
 	height := 14
@@ -81,7 +74,7 @@ func (m *appModel) initSubscriptionList() {
 		height = m.height - 10 // Leave room for header and help text
 	}
 
-	l := list.New(items, delegate, width, height)
+	l := list.New(items, newListDelegate(), width, height)
 	l.Title = "Select Subscription Plan"
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
@@ -185,7 +178,7 @@ func (m appModel) updateSubscription(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Open browser with checkout URL
 		if err := openBrowser(msg.checkoutURL); err != nil {
-			m.subscriptionErr = "Failed to open browser: " + err.Error()
+			m.subscriptionErr = "Failed to open browser: " + err.Error() + "\n   Open it manually: " + hyperlink(msg.checkoutURL, msg.checkoutURL)
 			return m, nil
 		}
 		m.subscriptionErr = ""
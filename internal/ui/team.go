@@ -0,0 +1,246 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// teamListItem is a seat of the enterprise workspace
+type teamListItem struct {
+	seat api.TeamSeat
+}
+
+func (i teamListItem) Title() string {
+	if i.seat.Status == "invited" {
+		return i.seat.Email + "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("(invited)")
+	}
+	return i.seat.Email
+}
+
+func (i teamListItem) Description() string {
+	role := i.seat.Role
+	if role == "" {
+		role = "member"
+	}
+	switch {
+	case i.seat.JoinedAt != nil:
+		return role + "  •  Joined " + formatTimeAgoSync(*i.seat.JoinedAt)
+	case i.seat.InvitedAt != nil:
+		return role + "  •  Invited " + formatTimeAgoSync(*i.seat.InvitedAt)
+	}
+	return role
+}
+
+func (i teamListItem) FilterValue() string { return i.seat.Email }
+
+type teamSeatsMsg struct {
+	seats *api.TeamSeats
+	err   error
+}
+
+type teamActionMsg struct {
+	message string
+	err     error
+}
+
+// initTeam opens the seat management screen and loads the seats
+func (m appModel) initTeam() (tea.Model, tea.Cmd) {
+	l := newList(nil, "👥  Team Seats", false)
+
+	h, v := docStyle.GetFrameSize()
+	if m.width > 0 && m.height > 0 {
+		l.SetSize(m.width-h, m.height-v-m.listChrome())
+	}
+
+	input := textinput.New()
+	input.Placeholder = "teammate@example.com"
+	input.CharLimit = 254
+	input.Width = 40
+
+	m.teamList = l
+	m.teamInviteInput = input
+	m.teamInviting = false
+	m.teamPendingRemove = nil
+	m.teamSeats = nil
+	m.teamMsg = "🔄 Loading seats..."
+	m.screen = screenTeam
+	return m, m.fetchTeamSeats()
+}
+
+func (m appModel) fetchTeamSeats() tea.Cmd {
+	return func() tea.Msg {
+		client, err := api.GetAPIClient()
+		if err != nil {
+			return teamSeatsMsg{err: err}
+		}
+		seats, err := client.GetTeamSeats()
+		return teamSeatsMsg{seats: seats, err: err}
+	}
+}
+
+func (m appModel) inviteTeamMember(email string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := api.GetAPIClient()
+		if err != nil {
+			return teamActionMsg{err: err}
+		}
+		if _, err := client.WithIdempotencyKey(api.NewIdempotencyKey()).InviteTeamMember(email); err != nil {
+			return teamActionMsg{err: err}
+		}
+		return teamActionMsg{message: "Invite sent to " + email}
+	}
+}
+
+func (m appModel) removeTeamMember(seat api.TeamSeat) tea.Cmd {
+	return func() tea.Msg {
+		client, err := api.GetAPIClient()
+		if err != nil {
+			return teamActionMsg{err: err}
+		}
+		if err := client.WithIdempotencyKey(api.NewIdempotencyKey()).RemoveTeamMember(seat.ID); err != nil {
+			return teamActionMsg{err: err}
+		}
+		return teamActionMsg{message: "Removed " + seat.Email}
+	}
+}
+
+func (m appModel) updateTeam(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case teamSeatsMsg:
+		if msg.err != nil {
+			m.teamMsg = "❌ Failed to load seats: " + msg.err.Error()
+			return m, nil
+		}
+		m.teamSeats = msg.seats
+		var items []list.Item
+		for _, seat := range msg.seats.Seats {
+			items = append(items, teamListItem{seat: seat})
+		}
+		m.teamList.SetItems(items)
+		m.teamMsg = ""
+		return m, nil
+
+	case teamActionMsg:
+		if msg.err != nil {
+			m.teamMsg = "❌ " + msg.err.Error()
+			return m, nil
+		}
+		m.teamMsg = "✅ " + msg.message
+		return m, m.fetchTeamSeats()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		// Typing an invite address
+		if m.teamInviting {
+			switch msg.String() {
+			case "esc":
+				m.teamInviting = false
+				m.teamInviteInput.Blur()
+				return m, nil
+			case "enter":
+				email := strings.TrimSpace(m.teamInviteInput.Value())
+				if !strings.Contains(email, "@") {
+					m.teamMsg = "⚠️  Enter a valid email address"
+					return m, nil
+				}
+				m.teamInviting = false
+				m.teamInviteInput.Blur()
+				m.teamMsg = "🔄 Inviting " + email + "..."
+				return m, m.inviteTeamMember(email)
+			}
+			var cmd tea.Cmd
+			m.teamInviteInput, cmd = m.teamInviteInput.Update(msg)
+			return m, cmd
+		}
+
+		// Confirming a removal
+		if m.teamPendingRemove != nil {
+			seat := *m.teamPendingRemove
+			m.teamPendingRemove = nil
+			if msg.String() != "y" && msg.String() != "Y" {
+				m.teamMsg = "Cancelled"
+				return m, nil
+			}
+			m.teamMsg = "🔄 Removing " + seat.Email + "..."
+			return m, m.removeTeamMember(seat)
+		}
+
+		switch msg.String() {
+		case "esc", "q":
+			m.screen = screenPremium
+			return m, nil
+		case "i":
+			if m.teamSeats != nil && m.teamSeats.TotalSeats > 0 && m.teamSeats.UsedSeats >= m.teamSeats.TotalSeats {
+				m.teamMsg = "⚠️  All seats are in use. Remove a member or add seats to your plan."
+				return m, nil
+			}
+			m.teamInviting = true
+			m.teamInviteInput.SetValue("")
+			m.teamMsg = ""
+			return m, m.teamInviteInput.Focus()
+		case "x", "delete":
+			i, ok := m.teamList.SelectedItem().(teamListItem)
+			if !ok {
+				return m, nil
+			}
+			if i.seat.Role == "owner" {
+				m.teamMsg = "⚠️  The workspace owner can't be removed"
+				return m, nil
+			}
+			seat := i.seat
+			if !config.ShouldConfirm(true) {
+				m.teamMsg = "🔄 Removing " + seat.Email + "..."
+				return m, m.removeTeamMember(seat)
+			}
+			m.teamPendingRemove = &seat
+			if seat.Status == "invited" {
+				m.teamMsg = fmt.Sprintf("Revoke the invite for %s? [y/N]", seat.Email)
+			} else {
+				m.teamMsg = fmt.Sprintf("Remove %s from the team? [y/N]", seat.Email)
+			}
+			return m, nil
+		case "r":
+			m.teamMsg = "🔄 Loading seats..."
+			return m, m.fetchTeamSeats()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.teamList, cmd = m.teamList.Update(msg)
+	return m, cmd
+}
+
+func (m appModel) viewTeam() string {
+	summary := "Loading..."
+	if m.teamSeats != nil {
+		summary = fmt.Sprintf("Seats: %d used", m.teamSeats.UsedSeats)
+		if m.teamSeats.TotalSeats > 0 {
+			summary = fmt.Sprintf("Seats: %d of %d used", m.teamSeats.UsedSeats, m.teamSeats.TotalSeats)
+		}
+	}
+
+	content := headerStyle.Render(summary) + "\n" + docStyle.Render(m.teamList.View())
+
+	if m.teamInviting {
+		content += "\n" + lipgloss.NewStyle().Padding(0, 1).Render("✉️  Invite: "+m.teamInviteInput.View())
+	}
+	if m.teamMsg != "" {
+		content += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Padding(0, 1).Render(m.teamMsg)
+	}
+
+	helpText := "[↑↓] Navigate  [i] Invite  [x] Remove  [r] Refresh  [Esc] Back"
+	if m.teamInviting {
+		helpText = "[Enter] Send invite  [Esc] Cancel"
+	}
+	return content + "\n" + helpStyle.Render(helpText)
+}
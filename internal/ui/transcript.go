@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// transcript records the actions of the running interactive session, nil
+// outside of one
+var transcript *config.Transcript
+
+// logAction adds an action to the session transcript. The transcript is a
+// nice-to-have, so failures are ignored.
+func logAction(format string, args ...interface{}) {
+	if transcript == nil {
+		return
+	}
+	_ = transcript.Record(fmt.Sprintf(format, args...))
+}
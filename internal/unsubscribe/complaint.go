@@ -0,0 +1,247 @@
+package unsubscribe
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+)
+
+// ComplaintDeadline is how long a sender gets to process an unsubscribe
+// before further mail counts against it. The GDPR sets no fixed delay; a
+// month is well past what any mailing list needs.
+const ComplaintDeadline = 30 * 24 * time.Hour
+
+// Violation is a sender that kept mailing after the deadline of a
+// successful unsubscribe
+type Violation struct {
+	Sender         string
+	Account        string
+	UnsubscribedAt time.Time
+	Deadline       time.Time
+	Messages       []imap.SenderMessage // Received after the deadline, oldest first
+	Audit          []config.AuditEntry  // Unsubscribe attempts for the sender
+}
+
+// recordAudit appends the outcome of unsubscribe attempts to the audit log
+func recordAudit(results []UnsubscribeResult, email string, at time.Time) error {
+	entries := make([]config.AuditEntry, 0, len(results))
+	for _, result := range results {
+		entries = append(entries, config.AuditEntry{
+			Time:    at,
+			Sender:  result.Sender,
+			Account: email,
+			Link:    result.Link,
+			Method:  result.Method,
+			Success: result.Success,
+			Error:   result.ErrorMsg,
+		})
+	}
+	return config.AppendAudit(entries...)
+}
+
+// FindViolations looks for mail received after the deadline from senders
+// the user unsubscribed from, limited to sender when it is not empty. Only
+// unsubscribes the audit log records as successful for the account count,
+// not senders imported or pulled into the unsubscribed list, and the
+// deadline runs from the last of them.
+func FindViolations(server, email, password string, folders []string, sender string, now time.Time) ([]Violation, error) {
+	store, err := config.LoadUnsubscribed()
+	if err != nil {
+		return nil, err
+	}
+	listed := make(map[string]bool, len(store.Newsletters))
+	for _, n := range store.Newsletters {
+		listed[config.SenderKey(n.Sender)] = true
+	}
+
+	entries, err := config.LoadAudit("")
+	if err != nil {
+		return nil, err
+	}
+	verified := make(map[string]time.Time)
+	for _, entry := range entries {
+		key := config.SenderKey(entry.Sender)
+		if !entry.Success || entry.Account != email || !listed[key] {
+			continue
+		}
+		if sender != "" && key != config.SenderKey(sender) {
+			continue
+		}
+		if entry.Time.After(verified[key]) {
+			verified[key] = entry.Time
+		}
+	}
+
+	pending := make(map[string]time.Time)
+	unsubscribedAt := make(map[string]time.Time)
+	for s, at := range verified {
+		deadline := at.Add(ComplaintDeadline)
+		if deadline.Before(now) {
+			pending[s] = deadline
+			unsubscribedAt[s] = at
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	found, err := imap.FindMessagesFrom(server, email, password, folders, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	for s, messages := range found {
+		sort.Slice(messages, func(i, j int) bool { return messages[i].Date.Before(messages[j].Date) })
+
+		var audit []config.AuditEntry
+		for _, entry := range entries {
+			// Entries from before accounts were recorded have none
+			if config.SenderKey(entry.Sender) == s && (entry.Account == "" || entry.Account == email) {
+				audit = append(audit, entry)
+			}
+		}
+
+		violations = append(violations, Violation{
+			Sender:         s,
+			Account:        email,
+			UnsubscribedAt: unsubscribedAt[s],
+			Deadline:       pending[s],
+			Messages:       messages,
+			Audit:          audit,
+		})
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Sender < violations[j].Sender })
+	return violations, nil
+}
+
+// Complaint is a pre-filled email objecting to mail sent after an unsubscribe
+type Complaint struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// DefaultComplaintRecipient guesses the privacy contact of a sender. Most
+// privacy policies name the real DPO address, which should be preferred.
+func DefaultComplaintRecipient(sender string) string {
+	domain := sender
+	if at := strings.LastIndex(sender, "@"); at != -1 {
+		domain = sender[at+1:]
+	}
+	return "privacy@" + domain
+}
+
+// NewComplaint drafts the complaint for a violation, addressed to to or the
+// guessed privacy contact when it is empty
+func NewComplaint(v Violation, to string) Complaint {
+	if to == "" {
+		to = DefaultComplaintRecipient(v.Sender)
+	}
+
+	method := ""
+	for _, entry := range v.Audit {
+		if entry.Success && entry.Method != "" {
+			method = " (" + entry.Method + ")"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("Dear Data Protection Officer,\n\n")
+	fmt.Fprintf(&b, "On %s I unsubscribed %s from the mailings of %s using the unsubscribe\n"+
+		"mechanism provided in your messages%s. I thereby objected to the processing\n"+
+		"of my personal data for direct marketing and withdrew any consent I had given.\n\n",
+		v.UnsubscribedAt.Format("2 January 2006"), v.Account, v.Sender, method)
+	b.WriteString("Under Article 21(3) GDPR my personal data may no longer be processed for\n" +
+		"direct marketing once I object, and under Article 7(3) GDPR withdrawing consent\n" +
+		"must be as easy as giving it. ")
+	fmt.Fprintf(&b, "Nevertheless I received %d further message(s) from\n%s more than %d days after unsubscribing:\n\n",
+		len(v.Messages), v.Sender, int(ComplaintDeadline.Hours()/24))
+	for _, msg := range v.Messages {
+		fmt.Fprintf(&b, "  %s  %s\n", msg.Date.Format("2006-01-02 15:04"), msg.Subject)
+	}
+	b.WriteString("\nPlease stop sending me marketing messages and confirm within one month, as\n" +
+		"required by Article 12(3) GDPR, that my address has been removed from your\n" +
+		"marketing lists. Otherwise I will lodge a complaint with the competent\n" +
+		"data protection authority.\n\n")
+	fmt.Fprintf(&b, "Kind regards,\n%s\n", v.Account)
+
+	return Complaint{
+		To:      to,
+		Subject: "Objection to direct marketing after unsubscribe (Art. 21 GDPR)",
+		Body:    b.String(),
+	}
+}
+
+// MailtoLink returns a link that opens the complaint in the user's mail client
+func (c Complaint) MailtoLink() string {
+	query := url.Values{}
+	query.Set("subject", c.Subject)
+	query.Set("body", c.Body)
+	return "mailto:" + c.To + "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// WriteEvidenceBundle writes a zip archive with the complaint, the audit log
+// entries and the messages received after the deadline, for sending to a
+// data protection authority
+func WriteEvidenceBundle(path string, v Violation, c Complaint) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	complaint := fmt.Sprintf("To: %s\nSubject: %s\n\n%s", c.To, c.Subject, c.Body)
+	if err := writeZipFile(zw, "complaint.txt", []byte(complaint)); err != nil {
+		return err
+	}
+
+	summary := struct {
+		Sender         string    `json:"sender"`
+		Account        string    `json:"account"`
+		UnsubscribedAt time.Time `json:"unsubscribed_at"`
+		Deadline       time.Time `json:"deadline"`
+		GeneratedAt    time.Time `json:"generated_at"`
+	}{v.Sender, v.Account, v.UnsubscribedAt, v.Deadline, time.Now()}
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"unsubscribe.json", summary},
+		{"audit.json", v.Audit},
+		{"messages.json", v.Messages},
+	}
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := writeZipFile(zw, file.name, data); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// writeZipFile adds a file to a zip archive
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,47 @@
+package unsubscribe
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+)
+
+// appendUnsubscribeNotes stores a note for each successful unsubscribe in
+// the configured mailbox folder, if notes are turned on
+func appendUnsubscribeNotes(results []UnsubscribeResult, settings config.UnsubscribeNotes, email, password, imapServer string) error {
+	if !settings.Enabled {
+		return nil
+	}
+	if email == "" || password == "" || imapServer == "" {
+		return fmt.Errorf("IMAP credentials required for unsubscribe notes")
+	}
+
+	var notes []imap.Note
+	for _, result := range results {
+		if result.Success {
+			notes = append(notes, unsubscribeNote(result, time.Now()))
+		}
+	}
+	if len(notes) == 0 {
+		return nil
+	}
+
+	folder := settings.Folder
+	if folder == "" {
+		folder = config.DefaultNotesFolder
+	}
+	return imap.AppendNotes(imapServer, email, password, folder, notes)
+}
+
+// unsubscribeNote renders the note for an unsubscribe
+func unsubscribeNote(result UnsubscribeResult, at time.Time) imap.Note {
+	body := fmt.Sprintf("Newsletter CLI unsubscribed you from %s.\r\n\r\n"+
+		"Sender: %s\r\n"+
+		"Date:   %s\r\n"+
+		"Method: %s\r\n"+
+		"Link:   %s\r\n",
+		result.Sender, result.Sender, at.Format("2006-01-02 15:04"), result.Method, result.Link)
+	return imap.Note{Subject: "Unsubscribed from " + result.Sender, Body: body}
+}
@@ -0,0 +1,156 @@
+package unsubscribe
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+const (
+	// outageThreshold failed requests in a row to one provider, each within
+	// outageWindow of the previous, mean the provider is treated as down
+	outageThreshold = 3
+	outageWindow    = 30 * time.Second
+
+	// perProviderConcurrency limits parallel requests to one provider, so a
+	// failing one is noticed before every request to it is in flight
+	perProviderConcurrency = 4
+)
+
+// providerNames maps the domains of common email service providers to the
+// name shown to the user
+var providerNames = map[string]string{
+	"list-manage.com":     "Mailchimp",
+	"mailchimp.com":       "Mailchimp",
+	"mcsv.net":            "Mailchimp",
+	"sendgrid.net":        "SendGrid",
+	"mailgun.org":         "Mailgun",
+	"klaviyo.com":         "Klaviyo",
+	"hubspot.com":         "HubSpot",
+	"hubspotemail.net":    "HubSpot",
+	"substack.com":        "Substack",
+	"beehiiv.com":         "beehiiv",
+	"convertkit.com":      "ConvertKit",
+	"ck.page":             "ConvertKit",
+	"brevo.com":           "Brevo",
+	"sendinblue.com":      "Brevo",
+	"createsend.com":      "Campaign Monitor",
+	"constantcontact.com": "Constant Contact",
+	"exacttarget.com":     "Salesforce Marketing Cloud",
+	"amazonses.com":       "Amazon SES",
+}
+
+// ProviderName returns a readable name for a provider domain
+func ProviderName(domain string) string {
+	if name, ok := providerNames[domain]; ok {
+		return name
+	}
+	return domain
+}
+
+// providerDomain returns the registrable domain an unsubscribe link points
+// at, e.g. list-manage.com for https://example.us1.list-manage.com/...
+func providerDomain(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	host := u.Hostname()
+	if u.Scheme == "mailto" {
+		host = u.Opaque
+		if host == "" {
+			host = u.Path
+		}
+		if at := strings.LastIndex(host, "@"); at != -1 {
+			host = host[at+1:]
+		}
+		host, _, _ = strings.Cut(host, "?")
+	}
+
+	return config.BaseDomain(strings.ToLower(host))
+}
+
+// statusError is an HTTP response that did not confirm the unsubscribe
+type statusError struct {
+	method string
+	code   int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.method, e.code)
+}
+
+// isProviderError reports whether err points at the provider being down or
+// overloaded rather than at a bad link
+func isProviderError(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.code >= 500 || status.code == 429
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// outageTracker notices providers that fail repeatedly during a batch
+type outageTracker struct {
+	mu       sync.Mutex
+	failures map[string][]time.Time // Recent consecutive failures by provider
+	down     map[string]bool
+}
+
+func newOutageTracker() *outageTracker {
+	return &outageTracker{
+		failures: make(map[string][]time.Time),
+		down:     make(map[string]bool),
+	}
+}
+
+// record notes the outcome of a request to domain
+func (t *outageTracker) record(domain string, result UnsubscribeResult, at time.Time) {
+	if domain == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !result.unreachable {
+		// Any other outcome shows the provider is up
+		delete(t.failures, domain)
+		return
+	}
+
+	failures := t.failures[domain]
+	if n := len(failures); n > 0 && at.Sub(failures[n-1]) > outageWindow {
+		failures = nil
+	}
+	failures = append(failures, at)
+	t.failures[domain] = failures
+	if len(failures) >= outageThreshold {
+		t.down[domain] = true
+	}
+}
+
+// isDown reports whether requests to domain should be paused
+func (t *outageTracker) isDown(domain string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.down[domain]
+}
+
+// Outages counts the paused unsubscribes per provider name
+func Outages(results []UnsubscribeResult) map[string]int {
+	outages := make(map[string]int)
+	for _, result := range results {
+		if result.Paused {
+			outages[ProviderName(result.Provider)]++
+		}
+	}
+	return outages
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"net/mail"
@@ -12,6 +13,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
 )
 
 // UnsubscribeResult represents the result of an unsubscribe attempt
@@ -20,12 +23,55 @@ type UnsubscribeResult struct {
 	Link     string
 	Success  bool
 	ErrorMsg string
+	Method   string // How the unsubscribe was performed, set on success
+	Provider string // Domain the unsubscribe link points at
+	Paused   bool   // Not attempted because the provider appears to be down
+
+	unreachable bool // Failed in a way that points at the provider, not the link
+}
+
+// Options configures UnsubscribeWith and BatchUnsubscribeWith. The zero
+// value sends the built-in templates, stores no notes and keeps no audit log.
+type Options struct {
+	Templates config.UnsubscribeTemplates // Emails sent for mailto: links
+	Notes     config.UnsubscribeNotes     // Notes stored in the mailbox on success
+	Audit     bool                        // Record attempts in the audit log in the config directory
+}
+
+// SettingsOptions returns the options the CLI unsubscribes with: templates
+// and notes from the settings, and the audit log
+func SettingsOptions() Options {
+	opts := Options{Audit: true}
+	if settings, err := config.LoadSettings(); err == nil {
+		opts.Templates = settings.UnsubscribeTemplates
+		opts.Notes = settings.UnsubscribeNotes
+	}
+	return opts
 }
 
 // Unsubscribe attempts to unsubscribe from a newsletter using the provided link
 // Supports both HTTP (GET/POST) and mailto: links
 // email, password, and imapServer are required for mailto: links to send via SMTP
+// Every attempt is recorded in the audit log. When enabled in the settings, a
+// note documenting a successful unsubscribe is stored in the user's mailbox.
 func Unsubscribe(sender, unsubscribeLink string, email, password, imapServer string) UnsubscribeResult {
+	return UnsubscribeWith(sender, unsubscribeLink, email, password, imapServer, SettingsOptions())
+}
+
+// UnsubscribeWith is Unsubscribe with explicit options
+func UnsubscribeWith(sender, unsubscribeLink string, email, password, imapServer string, opts Options) UnsubscribeResult {
+	result := unsubscribe(sender, unsubscribeLink, email, password, imapServer, opts.Templates)
+	if opts.Audit {
+		_ = recordAudit([]UnsubscribeResult{result}, email, time.Now())
+	}
+	_ = appendUnsubscribeNotes([]UnsubscribeResult{result}, opts.Notes, email, password, imapServer) // Best effort, the unsubscribe itself worked
+	return result
+}
+
+func unsubscribe(sender, unsubscribeLink string, email, password, imapServer string, templates config.UnsubscribeTemplates) UnsubscribeResult {
+	if err := config.CheckWritable(); err != nil {
+		return UnsubscribeResult{Sender: sender, Link: unsubscribeLink, ErrorMsg: err.Error()}
+	}
 	result := UnsubscribeResult{
 		Sender: sender,
 		Link:   unsubscribeLink,
@@ -42,7 +88,7 @@ func Unsubscribe(sender, unsubscribeLink string, email, password, imapServer str
 			result.ErrorMsg = "SMTP credentials required for mailto: links"
 			return result
 		}
-		return unsubscribeMailto(sender, unsubscribeLink, email, password, imapServer)
+		return unsubscribeMailto(sender, unsubscribeLink, email, password, imapServer, templates)
 	}
 
 	// Handle HTTP links
@@ -54,18 +100,21 @@ func Unsubscribe(sender, unsubscribeLink string, email, password, imapServer str
 	// Try POST first (most common for unsubscribe), then GET
 	if err := unsubscribePOST(unsubscribeLink); err == nil {
 		result.Success = true
+		result.Method = "HTTP POST"
 		return result
 	}
 
 	// If POST fails, try GET
 	if err := unsubscribeGET(unsubscribeLink); err == nil {
 		result.Success = true
+		result.Method = "HTTP GET"
 		return result
 	}
 
 	// Both POST and GET failed - try GET one more time to get the error
 	err := unsubscribeGET(unsubscribeLink)
 	result.ErrorMsg = fmt.Sprintf("Failed to unsubscribe: %v", err)
+	result.unreachable = isProviderError(err)
 	return result
 }
 
@@ -100,7 +149,7 @@ func unsubscribePOST(link string) error {
 		return nil
 	}
 
-	return fmt.Errorf("POST returned status %d", resp.StatusCode)
+	return &statusError{method: "POST", code: resp.StatusCode}
 }
 
 // unsubscribeGET attempts to unsubscribe via HTTP GET
@@ -135,23 +184,59 @@ func unsubscribeGET(link string) error {
 		return nil
 	}
 
-	return fmt.Errorf("GET returned status %d", resp.StatusCode)
+	return &statusError{method: "GET", code: resp.StatusCode}
 }
 
-// BatchUnsubscribe processes multiple unsubscribe requests concurrently
+// BatchUnsubscribe processes multiple unsubscribe requests concurrently, a
+// few at a time per provider. When a provider keeps failing, the remaining
+// requests to it are paused rather than sent into an outage.
 // email, password, and imapServer are required for mailto: links
 func BatchUnsubscribe(requests []struct {
 	Sender string
 	Link   string
 }, email, password, imapServer string) []UnsubscribeResult {
+	return BatchUnsubscribeWith(requests, email, password, imapServer, SettingsOptions())
+}
+
+// BatchUnsubscribeWith is BatchUnsubscribe with explicit options
+func BatchUnsubscribeWith(requests []struct {
+	Sender string
+	Link   string
+}, email, password, imapServer string, opts Options) []UnsubscribeResult {
 	results := make([]UnsubscribeResult, len(requests))
 	resultChan := make(chan UnsubscribeResult, len(requests))
-
-	// Process all requests concurrently
-	for _, req := range requests {
-		go func(sender, link string) {
-			resultChan <- Unsubscribe(sender, link, email, password, imapServer)
-		}(req.Sender, req.Link)
+	tracker := newOutageTracker()
+
+	// Providers are handled concurrently, with a few requests to each at once
+	byProvider := make(map[string][]int)
+	for idx, req := range requests {
+		domain := providerDomain(req.Link)
+		byProvider[domain] = append(byProvider[domain], idx)
+	}
+	for domain, indices := range byProvider {
+		slots := make(chan struct{}, perProviderConcurrency)
+		go func(domain string, indices []int) {
+			for _, idx := range indices {
+				slots <- struct{}{}
+				go func(sender, link string) {
+					defer func() { <-slots }()
+					if tracker.isDown(domain) {
+						resultChan <- UnsubscribeResult{
+							Sender:   sender,
+							Link:     link,
+							Provider: domain,
+							Paused:   true,
+							ErrorMsg: ProviderName(domain) + " appears to be down, retry later",
+						}
+						return
+					}
+					result := unsubscribe(sender, link, email, password, imapServer, opts.Templates)
+					result.Provider = domain
+					tracker.record(domain, result, time.Now())
+					resultChan <- result
+				}(requests[idx].Sender, requests[idx].Link)
+			}
+		}(domain, indices)
 	}
 
 	// Collect results
@@ -159,11 +244,18 @@ func BatchUnsubscribe(requests []struct {
 		results[i] = <-resultChan
 	}
 
+	if opts.Audit {
+		_ = recordAudit(results, email, time.Now())
+	}
+
+	// Notes go over one connection rather than one per newsletter
+	_ = appendUnsubscribeNotes(results, opts.Notes, email, password, imapServer) // Best effort
+
 	return results
 }
 
 // unsubscribeMailto handles mailto: unsubscribe links by sending an email via SMTP
-func unsubscribeMailto(sender, mailtoLink, email, password, imapServer string) UnsubscribeResult {
+func unsubscribeMailto(sender, mailtoLink, email, password, imapServer string, templates config.UnsubscribeTemplates) UnsubscribeResult {
 	result := UnsubscribeResult{
 		Sender: sender,
 		Link:   mailtoLink,
@@ -186,9 +278,9 @@ func unsubscribeMailto(sender, mailtoLink, email, password, imapServer string) U
 		return result
 	}
 
-	// Extract subject and body from query parameters
-	subject := "Unsubscribe"
-	body := "Please unsubscribe me from your mailing list."
+	// Extract subject and body from query parameters; the list's own values
+	// win over the user's template since list servers often match on them
+	subject, body := renderTemplate(resolveTemplate(templates, toEmail), sender, email)
 	if u.Query().Get("subject") != "" {
 		subject = u.Query().Get("subject")
 	}
@@ -210,6 +302,7 @@ func unsubscribeMailto(sender, mailtoLink, email, password, imapServer string) U
 	}
 
 	result.Success = true
+	result.Method = "email to " + toEmail
 	return result
 }
 
@@ -291,7 +384,11 @@ func sendUnsubscribeEmail(fromEmail, password, smtpServer, toEmail, subject, bod
 	// Create message
 	message := fmt.Sprintf("From: %s\r\n", from.Address)
 	message += fmt.Sprintf("To: %s\r\n", to.Address)
-	message += fmt.Sprintf("Subject: %s\r\n", subject)
+	// Templates in other languages are not plain ASCII
+	message += fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	message += "MIME-Version: 1.0\r\n"
+	message += "Content-Type: text/plain; charset=utf-8\r\n"
+	message += "Content-Transfer-Encoding: 8bit\r\n"
 	message += "\r\n"
 	message += body + "\r\n"
 
@@ -0,0 +1,58 @@
+package unsubscribe
+
+import (
+	"fmt"
+	"time"
+)
+
+// testListAddress stands in for a mailing list when previewing and testing
+// mailto unsubscribes
+const testListAddress = "unsubscribe@list.example.com"
+
+// SMTPCheck describes a test email sent through the mailto unsubscribe path
+type SMTPCheck struct {
+	Server  string        // SMTP server derived from the IMAP server
+	To      string        // Where the test was sent
+	Latency time.Duration // Time to connect, authenticate and send
+}
+
+// PreviewMailto returns the subject and body a mailto unsubscribe to
+// recipient would be sent with, using the configured templates. Lists that
+// put a subject or body in their link override these.
+func PreviewMailto(recipient, sender, account string) (subject, body string) {
+	if recipient == "" {
+		recipient = testListAddress
+	}
+	return renderTemplate(resolveTemplate(SettingsOptions().Templates, recipient), sender, account)
+}
+
+// SMTPServerFor returns the SMTP server mailto unsubscribes for an account
+// on imapServer are sent through
+func SMTPServerFor(imapServer string) (string, error) {
+	return getSMTPServer(imapServer)
+}
+
+// TestSMTP sends the unsubscribe email to to, or to the account itself when
+// to is empty, exactly as a mailto unsubscribe would, but with a subject
+// marking it as a test
+func TestSMTP(email, password, imapServer, to string) (*SMTPCheck, error) {
+	if to == "" {
+		to = email
+	}
+
+	server, err := getSMTPServer(imapServer)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine SMTP server: %w", err)
+	}
+
+	subject, body := PreviewMailto(testListAddress, testListAddress, email)
+	subject = "[Newsletter CLI test] " + subject
+	body = "This is a test of the email Newsletter CLI sends for mailto: unsubscribe\r\n" +
+		"links. Nothing was unsubscribed; it can be deleted.\r\n\r\n" + body
+
+	start := time.Now()
+	if err := sendUnsubscribeEmail(email, password, server, to, subject, body); err != nil {
+		return &SMTPCheck{Server: server, To: to}, err
+	}
+	return &SMTPCheck{Server: server, To: to, Latency: time.Since(start)}, nil
+}
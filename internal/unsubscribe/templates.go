@@ -0,0 +1,80 @@
+package unsubscribe
+
+import (
+	"strings"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// defaultTemplates are used when the user has not configured a template for
+// the selected language
+var defaultTemplates = map[string]config.MailTemplate{
+	"en": {
+		Subject: "Unsubscribe",
+		Body:    "Please unsubscribe {{account}} from your mailing list.",
+	},
+	"de": {
+		Subject: "Abmelden",
+		Body:    "Bitte melden Sie {{account}} von Ihrem Verteiler ab.",
+	},
+	"fr": {
+		Subject: "Désinscription",
+		Body:    "Merci de désinscrire {{account}} de votre liste de diffusion.",
+	},
+	"es": {
+		Subject: "Cancelar suscripción",
+		Body:    "Por favor, den de baja {{account}} de su lista de correo.",
+	},
+}
+
+// resolveTemplate picks the template for an unsubscribe email to recipient:
+// a provider override matching the recipient's domain, then the user's
+// template for their language, then the built-in one. Empty fields fall
+// back to the next level.
+func resolveTemplate(templates config.UnsubscribeTemplates, recipient string) config.MailTemplate {
+	lang := strings.ToLower(templates.Language)
+	if lang == "" {
+		lang = "en"
+	}
+
+	result := defaultTemplates["en"]
+	if t, ok := defaultTemplates[lang]; ok {
+		result = t
+	}
+	result = mergeTemplate(result, templates.Languages[lang])
+
+	domain := strings.ToLower(recipient)
+	if at := strings.LastIndex(domain, "@"); at != -1 {
+		domain = domain[at+1:]
+	}
+	for provider, t := range templates.Providers {
+		provider = strings.ToLower(strings.TrimPrefix(provider, "@"))
+		if domain == provider || strings.HasSuffix(domain, "."+provider) {
+			result = mergeTemplate(result, t)
+			break
+		}
+	}
+	return result
+}
+
+// mergeTemplate overlays the non-empty fields of override on base
+func mergeTemplate(base, override config.MailTemplate) config.MailTemplate {
+	if override.Subject != "" {
+		base.Subject = override.Subject
+	}
+	if override.Body != "" {
+		base.Body = override.Body
+	}
+	return base
+}
+
+// renderTemplate fills in the placeholders of a template
+func renderTemplate(t config.MailTemplate, sender, account string) (subject, body string) {
+	r := strings.NewReplacer(
+		"{{sender}}", sender,
+		"{{account}}", account,
+		"{{date}}", time.Now().Format("2006-01-02"),
+	)
+	return r.Replace(t.Subject), r.Replace(t.Body)
+}
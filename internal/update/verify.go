@@ -0,0 +1,391 @@
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	releaseTagURL   = "https://api.github.com/repos/" + githubOwner + "/" + githubRepo + "/releases/tags/"
+	manifestName    = "checksums.txt"
+	signatureSuffix = ".minisig"
+	downloadTimeout = 2 * time.Minute
+)
+
+// releaseKeys are the minisign public keys release manifests are signed
+// with. They ship in the binary so a compromised release page cannot swap
+// them; add the new key here before rotating and keep the old one until no
+// supported release is signed with it. The maintainer adds the public key
+// of the MINISIGN_KEY_FILE used by the release workflow; until then
+// releases can't be verified and the update commands are not offered.
+var releaseKeys = []string{}
+
+// ErrNoReleaseKeys is returned when this build pins no release signing key
+var ErrNoReleaseKeys = errors.New("no release signing key is built into this binary")
+
+// Asset is a file attached to a GitHub release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// PublicKey is a parsed minisign public key
+type PublicKey struct {
+	ID  [8]byte
+	Key ed25519.PublicKey
+}
+
+// KeyID formats the key ID the way minisign prints it
+func (k PublicKey) KeyID() string {
+	return fmt.Sprintf("%016X", binary.LittleEndian.Uint64(k.ID[:]))
+}
+
+// HasReleaseKeys reports whether this build pins a release signing key
+func HasReleaseKeys() bool {
+	return len(releaseKeys) > 0
+}
+
+// ReleaseKeys returns the pinned release signing keys
+func ReleaseKeys() ([]PublicKey, error) {
+	if len(releaseKeys) == 0 {
+		return nil, ErrNoReleaseKeys
+	}
+	keys := make([]PublicKey, 0, len(releaseKeys))
+	for _, encoded := range releaseKeys {
+		key, err := ParsePublicKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParsePublicKey decodes a minisign public key, either the bare base64 line
+// or the full key file with its comment
+func ParsePublicKey(encoded string) (PublicKey, error) {
+	var key PublicKey
+	lines := strings.Split(strings.TrimSpace(encoded), "\n")
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != "Ed" {
+		return key, errors.New("invalid minisign public key")
+	}
+	copy(key.ID[:], raw[2:10])
+	key.Key = ed25519.PublicKey(raw[10:])
+	return key, nil
+}
+
+// Signature is a parsed minisign signature file
+type Signature struct {
+	Algorithm      string // "Ed" signs the file, "ED" its BLAKE2b-512 hash
+	KeyID          [8]byte
+	Signature      []byte
+	TrustedComment string
+	GlobalSig      []byte
+}
+
+// ParseSignature decodes the four-line minisign signature format
+func ParseSignature(data []byte) (*Signature, error) {
+	lines := strings.Split(strings.TrimRight(string(data), "\r\n"), "\n")
+	if len(lines) < 4 {
+		return nil, errors.New("invalid minisign signature: too short")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return nil, errors.New("invalid minisign signature")
+	}
+	trusted, ok := strings.CutPrefix(strings.TrimRight(lines[2], "\r"), "trusted comment: ")
+	if !ok {
+		return nil, errors.New("invalid minisign signature: missing trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return nil, errors.New("invalid minisign signature: bad global signature")
+	}
+
+	sig := &Signature{
+		Algorithm:      string(raw[:2]),
+		Signature:      raw[10:],
+		TrustedComment: trusted,
+		GlobalSig:      global,
+	}
+	copy(sig.KeyID[:], raw[2:10])
+	return sig, nil
+}
+
+// Verify checks the signature of data against the given keys and returns the
+// key that signed it
+func (s *Signature) Verify(data []byte, keys []PublicKey) (PublicKey, error) {
+	var key PublicKey
+	found := false
+	for _, k := range keys {
+		if k.ID == s.KeyID {
+			key, found = k, true
+			break
+		}
+	}
+	if !found {
+		return key, fmt.Errorf("signed by unknown key %016X", binary.LittleEndian.Uint64(s.KeyID[:]))
+	}
+
+	message := data
+	switch s.Algorithm {
+	case "Ed":
+	case "ED":
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	default:
+		return key, fmt.Errorf("unsupported signature algorithm %q", s.Algorithm)
+	}
+
+	if !ed25519.Verify(key.Key, message, s.Signature) {
+		return key, errors.New("signature does not match")
+	}
+	// The trusted comment is covered by its own signature
+	if !ed25519.Verify(key.Key, append(append([]byte{}, s.Signature...), s.TrustedComment...), s.GlobalSig) {
+		return key, errors.New("trusted comment signature does not match")
+	}
+	return key, nil
+}
+
+// Manifest maps release asset names to their SHA-256 checksums
+type Manifest map[string]string
+
+// ParseManifest reads a checksums file in sha256sum format
+func ParseManifest(data []byte) Manifest {
+	manifest := make(Manifest)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 {
+			manifest[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+		}
+	}
+	return manifest
+}
+
+// VerifyAsset checks a downloaded release asset against the manifest
+func (m Manifest) VerifyAsset(name string, data []byte) error {
+	want, ok := m[name]
+	if !ok {
+		return fmt.Errorf("%s is not listed in the release manifest", name)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != want {
+		return fmt.Errorf("checksum mismatch for %s", name)
+	}
+	return nil
+}
+
+// VerifyReport describes a successful check of the installed binary
+type VerifyReport struct {
+	Version        string
+	KeyID          string
+	TrustedComment string
+	Archive        string
+	Binary         string
+	SHA256         string
+}
+
+// VerifyInstalled checks the running binary against the signed manifest of
+// the release it claims to be: the manifest signature against the pinned
+// keys, the platform archive against the manifest, and the binary in that
+// archive against the executable on disk
+func VerifyInstalled(version string) (*VerifyReport, error) {
+	if version == "" || strings.HasPrefix(version, "dev") || strings.HasPrefix(version, "SNAPSHOT") {
+		return nil, errors.New("development builds are not published and cannot be verified")
+	}
+	tag := "v" + strings.TrimPrefix(version, "v")
+
+	keys, err := ReleaseKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := releaseAssets(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestData, err := download(assets, manifestName)
+	if err != nil {
+		return nil, err
+	}
+	sigData, err := download(assets, manifestName+signatureSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("release %s is not signed: %w", tag, err)
+	}
+	sig, err := ParseSignature(sigData)
+	if err != nil {
+		return nil, err
+	}
+	key, err := sig.Verify(manifestData, keys)
+	if err != nil {
+		return nil, fmt.Errorf("manifest signature: %w", err)
+	}
+	manifest := ParseManifest(manifestData)
+
+	archiveName := ArchiveName(version, runtime.GOOS, runtime.GOARCH)
+	archive, err := download(assets, archiveName)
+	if err != nil {
+		return nil, err
+	}
+	if err := manifest.VerifyAsset(archiveName, archive); err != nil {
+		return nil, err
+	}
+
+	released, err := extractBinary(archiveName, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	installed, err := os.ReadFile(exe)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(installed, released) {
+		return nil, fmt.Errorf("%s does not match the %s release binary", exe, tag)
+	}
+
+	sum := sha256.Sum256(installed)
+	return &VerifyReport{
+		Version:        tag,
+		KeyID:          key.KeyID(),
+		TrustedComment: sig.TrustedComment,
+		Archive:        archiveName,
+		Binary:         exe,
+		SHA256:         hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// ArchiveName returns the release archive for a platform, following the
+// name template in .goreleaser.yml
+func ArchiveName(version, goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s%s", githubRepo, strings.TrimPrefix(version, "v"), goos, goarch, ext)
+}
+
+// releaseAssets lists the assets of the release with the given tag
+func releaseAssets(tag string) ([]Asset, error) {
+	client := &http.Client{Timeout: timeout}
+	req, err := http.NewRequest("GET", releaseTagURL+tag, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("release %s not found", tag)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	var release struct {
+		Assets []Asset `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, err
+	}
+	return release.Assets, nil
+}
+
+// download fetches the named release asset
+func download(assets []Asset, name string) ([]byte, error) {
+	url := ""
+	for _, a := range assets {
+		if a.Name == name {
+			url = a.URL
+			break
+		}
+	}
+	if url == "" {
+		return nil, fmt.Errorf("release has no %s", name)
+	}
+
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: unexpected status: %d", name, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// extractBinary returns the newsletter-cli executable from a release archive
+func extractBinary(archiveName string, archive []byte) ([]byte, error) {
+	if strings.HasSuffix(archiveName, ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range zr.File {
+			if path.Base(f.Name) == githubRepo+".exe" {
+				rc, err := f.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer rc.Close()
+				return io.ReadAll(rc)
+			}
+		}
+		return nil, fmt.Errorf("%s contains no binary", archiveName)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s contains no binary", archiveName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == githubRepo {
+			return io.ReadAll(tr)
+		}
+	}
+}
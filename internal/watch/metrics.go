@@ -0,0 +1,132 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/api"
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// metrics counts what the daemon did since it started
+type metrics struct {
+	mu         sync.Mutex
+	scans      int
+	scanErrors int
+	found      map[string]int // Account -> newsletter senders found by its last scan
+	queued     int
+	lastScan   time.Time
+}
+
+var daemonMetrics = &metrics{}
+
+// recordScan counts one scan of one account
+func (m *metrics) recordScan(result ScanResult, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err != nil {
+		m.scanErrors++
+		return
+	}
+	m.scans++
+	if m.found == nil {
+		m.found = make(map[string]int)
+	}
+	m.found[result.Account] = result.Scanned
+	m.queued += result.Queued
+	m.lastScan = time.Now()
+}
+
+// ServeMetrics exposes the daemon's counters in the Prometheus text format
+// on addr at /metrics. Close the returned server to stop it.
+func ServeMetrics(addr string) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		daemonMetrics.write(w)
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("❌ Metrics server: %v", err)
+		}
+	}()
+	return server, nil
+}
+
+// write renders the metrics. Unsubscribes are counted from the audit log,
+// so they include the ones made in the app and survive daemon restarts.
+func (m *metrics) write(w io.Writer) {
+	m.mu.Lock()
+	scans, scanErrors, queued, lastScan := m.scans, m.scanErrors, m.queued, m.lastScan
+	found := make([]sample, 0, len(m.found))
+	for account, count := range m.found {
+		found = append(found, sample{fmt.Sprintf("account=%q", account), count})
+	}
+	m.mu.Unlock()
+	sort.Slice(found, func(i, j int) bool { return found[i].labels < found[j].labels })
+
+	writeMetric(w, "newsletter_cli_scans_total", "counter", "Account scans run by the watch daemon.",
+		sample{`result="success"`, scans}, sample{`result="error"`, scanErrors})
+	if len(found) > 0 {
+		writeMetric(w, "newsletter_cli_newsletters_found", "gauge", "Newsletter senders found by the last scan of each account.",
+			found...)
+	}
+	writeMetric(w, "newsletter_cli_new_senders_total", "counter", "New senders queued for review by daemon scans.",
+		sample{"", queued})
+	if !lastScan.IsZero() {
+		writeMetric(w, "newsletter_cli_last_scan_timestamp_seconds", "gauge", "Time of the last successful scan.",
+			sample{"", int(lastScan.Unix())})
+	}
+
+	if entries, err := config.LoadAudit(""); err == nil {
+		succeeded, failed := 0, 0
+		for _, entry := range entries {
+			if entry.Success {
+				succeeded++
+			} else {
+				failed++
+			}
+		}
+		writeMetric(w, "newsletter_cli_unsubscribes_total", "counter", "Unsubscribe attempts recorded in the audit log.",
+			sample{`result="success"`, succeeded}, sample{`result="failure"`, failed})
+	}
+
+	if failures, err := api.SyncFailures(); err == nil {
+		writeMetric(w, "newsletter_cli_sync_errors_total", "counter", "Premium sync attempts that failed, including retries of queued changes.",
+			sample{"", failures})
+	}
+	if pending, err := api.LoadPendingSyncs(); err == nil {
+		writeMetric(w, "newsletter_cli_sync_queue_pending", "gauge", "Premium changes that failed to sync and are queued for retry.",
+			sample{"", len(pending)})
+	}
+}
+
+// sample is one value of a metric, with its labels in exposition syntax
+type sample struct {
+	labels string
+	value  int
+}
+
+func writeMetric(w io.Writer, name, kind, help string, samples ...sample) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	for _, s := range samples {
+		if s.labels == "" {
+			fmt.Fprintf(w, "%s %d\n", name, s.value)
+		} else {
+			fmt.Fprintf(w, "%s{%s} %d\n", name, s.labels, s.value)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package watch
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+)
+
+// noiseCandidates is how many senders an alert suggests cutting
+const noiseCandidates = 3
+
+// checkNoiseBudget records this week's volume from the scan results and
+// alerts once per week when it exceeds the user's noise budget
+func checkNoiseBudget(results []ScanResult, now time.Time) error {
+	settings, err := config.LoadSettings()
+	if err != nil || settings.NoiseBudget <= 0 {
+		return err
+	}
+
+	store, err := config.LoadNoise()
+	if err != nil {
+		return err
+	}
+	var week *config.NoiseWeek
+	for _, result := range results {
+		if result.Volume != nil {
+			week = store.SetAccountVolume(now, result.Account, result.Volume)
+		}
+	}
+	if week == nil {
+		return nil // Nothing measured this pass
+	}
+
+	total := week.Total()
+	if total > settings.NoiseBudget && week.AlertedAt.IsZero() {
+		message := NoiseSummary(week, settings)
+		log.Printf("🔊 %s", message)
+		if err := notify("Newsletter noise budget exceeded", message); err != nil {
+			log.Printf("Desktop notification failed: %v", err)
+		}
+		week.AlertedAt = now
+	}
+	return config.SaveNoise(store)
+}
+
+// NoiseSummary describes a week over budget along with the senders worth
+// cutting first. Kept and unsubscribed senders are not suggested.
+func NoiseSummary(week *config.NoiseWeek, settings *config.Settings) string {
+	unsubscribed, _ := config.GetUnsubscribedList()
+	var trusted []string
+	if accounts, err := config.GetAllAccounts(); err == nil {
+		trusted = config.TrustedDomains(accounts)
+	}
+
+	var candidates []string
+	for _, v := range week.TopSenders(len(unsubscribed) + len(settings.KeepList) + noiseCandidates) {
+		sender := config.NewSenderIdentity("", v.Sender)
+		if unsubscribed[sender.Key()] || config.IsKept(sender, settings.KeepList, trusted) {
+			continue
+		}
+		candidates = append(candidates, fmt.Sprintf("%s (%d)", v.Sender, v.Count))
+		if len(candidates) == noiseCandidates {
+			break
+		}
+	}
+
+	summary := fmt.Sprintf("%d newsletter emails this week, budget is %d.", week.Total(), settings.NoiseBudget)
+	if len(candidates) > 0 {
+		summary += " Consider cutting " + strings.Join(candidates, ", ")
+	}
+	return summary
+}
@@ -0,0 +1,43 @@
+package watch
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// notify shows a desktop notification
+func notify(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("notify-send", "--app-name=newsletter-cli", title, message)
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleQuote(message), appleQuote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf(`[reflection.assembly]::loadwithpartialname('System.Windows.Forms') | Out-Null;
+$n = New-Object System.Windows.Forms.NotifyIcon;
+$n.Icon = [System.Drawing.SystemIcons]::Information;
+$n.Visible = $true;
+$n.ShowBalloonTip(10000, '%s', '%s', 'Info');
+Start-Sleep -Seconds 10;
+$n.Dispose()`, psQuote(title), psQuote(message))
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+		return cmd.Start() // Keeps the balloon open in the background
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+	return cmd.Run()
+}
+
+// psQuote escapes a string for a single-quoted PowerShell literal
+func psQuote(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+// appleQuote quotes a string for AppleScript, which only knows \" and \\
+func appleQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
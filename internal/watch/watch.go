@@ -0,0 +1,174 @@
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/imap"
+	"github.com/loickal/newsletter-cli/internal/schedule"
+)
+
+// baselineWindow is how far back the first scan of an account looks. Senders
+// found there become known without being queued for review.
+const baselineWindow = 30 * 24 * time.Hour
+
+// ScanResult summarizes one scan of one account
+type ScanResult struct {
+	Account  string
+	Baseline bool // First scan, senders were recorded without being queued
+	Scanned  int  // Newsletter senders found in the window
+	Queued   int  // New senders added to the review queue
+
+	// Emails per newsletter sender this week, nil when the scan did not
+	// cover the week so far
+	Volume map[string]int
+}
+
+// ScanAccount looks for newsletters received since the account's last scan
+// and queues senders that have never been seen for review
+func ScanAccount(account config.Account) (ScanResult, error) {
+	result := ScanResult{Account: account.Email}
+
+	password, err := config.Decrypt(account.Password)
+	if err != nil {
+		return result, fmt.Errorf("failed to decrypt password: %w", err)
+	}
+
+	store, err := config.LoadReviewStore()
+	if err != nil {
+		return result, err
+	}
+
+	now := time.Now()
+	lastScan, scanned := store.LastScan[account.Email]
+	since := now.Add(-baselineWindow)
+	if scanned {
+		since = lastScan
+	}
+	result.Baseline = !scanned
+
+	var folders []string
+	budget := 0
+	if settings, err := config.LoadSettings(); err == nil {
+		folders = settings.Folders
+		budget = settings.NoiseBudget
+	}
+
+	// With a noise budget the scan covers the whole week so far, so the
+	// weekly volume can be counted. Known senders keep this from queuing
+	// anything twice.
+	weekStart := config.WeekStart(now)
+	measureVolume := budget > 0 && scanned && !lastScan.Before(weekStart)
+	if measureVolume {
+		since = weekStart
+	}
+
+	stats, _, err := imap.FetchFolderStats(account.Server, account.Email, password, since, folders)
+	if err != nil {
+		// An empty window is not an error for the daemon
+		stats = nil
+		if !errors.Is(err, imap.ErrNoEmails) {
+			return result, err
+		}
+	}
+
+	unsubscribed, _ := config.GetUnsubscribedList()
+
+	// Kept senders and the user's own providers are never queued
+	var keepList []string
+	if settings, err := config.LoadSettings(); err == nil {
+		keepList = settings.KeepList
+	}
+	var trusted []string
+	if accounts, err := config.GetAllAccounts(); err == nil {
+		trusted = config.TrustedDomains(accounts)
+	}
+
+	// Reload in case the interactive app resolved items while we were fetching
+	store, err = config.LoadReviewStore()
+	if err != nil {
+		return result, err
+	}
+
+	if measureVolume {
+		result.Volume = make(map[string]int, len(stats))
+		for _, s := range stats {
+			result.Volume[s.Sender.Key()] = s.Count
+		}
+	}
+
+	for _, s := range stats {
+		result.Scanned++
+		key := s.Sender.Key()
+		if store.IsKnownSender(account.Email, key) || unsubscribed[key] || config.IsKept(s.Sender, keepList, trusted) {
+			continue
+		}
+		if result.Baseline {
+			store.AddKnownSender(account.Email, key)
+			continue
+		}
+		store.AddPending(config.PendingReview{
+			Sender:      key,
+			Account:     account.Email,
+			Count:       s.Count,
+			Unsubscribe: s.Unsubscribe,
+			DetectedAt:  now,
+			LastSeenAt:  now,
+		})
+		result.Queued++
+	}
+
+	store.LastScan[account.Email] = now
+	if err := config.SaveReviewStore(store); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Run scans all configured accounts every interval until stop is closed.
+// With once set it performs a single pass and returns. After the first pass,
+// scans follow the local clock with some jitter and scans missed while the
+// machine was asleep are skipped.
+func Run(interval time.Duration, once bool, stop <-chan struct{}) error {
+	sched := schedule.New(interval)
+	for {
+		accounts, err := config.GetAllAccounts()
+		if err != nil {
+			return err
+		}
+		if len(accounts) == 0 {
+			return fmt.Errorf("no accounts configured, run 'newsletter-cli login' first")
+		}
+
+		var results []ScanResult
+		for _, account := range accounts {
+			result, err := ScanAccount(account)
+			daemonMetrics.recordScan(result, err)
+			if err != nil {
+				log.Printf("❌ %s: %v", account.Email, err)
+				continue
+			}
+			if result.Baseline {
+				log.Printf("📋 %s: recorded %d existing newsletter(s) as baseline", account.Email, result.Scanned)
+			} else {
+				log.Printf("📬 %s: %d newsletter(s) checked, %d new queued for review", account.Email, result.Scanned, result.Queued)
+			}
+			results = append(results, result)
+		}
+
+		if err := checkNoiseBudget(results, time.Now()); err != nil {
+			log.Printf("❌ Noise budget: %v", err)
+		}
+
+		if once {
+			return nil
+		}
+
+		if !sched.Wait(stop) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package newsletter embeds the newsletter-cli analysis and unsubscribe
+// engines in other Go programs, such as bots, servers or GUIs.
+//
+// Analyze scans an IMAP account for newsletters and Unsubscribe or
+// UnsubscribeAll act on the links it finds:
+//
+//	account := newsletter.Account{Email: "me@example.com", Password: appPassword}
+//	report, err := newsletter.Analyze(account, newsletter.Options{Window: "3m"})
+//	if err != nil {
+//		return err
+//	}
+//	for _, n := range report.Newsletters {
+//		if n.Count > 20 && !n.Transactional {
+//			result := newsletter.Unsubscribe(account, n.Sender, n.Unsubscribe)
+//			fmt.Println(n.Sender, result.Success)
+//		}
+//	}
+//
+// The types here are a stable API: fields are only ever added. Nothing is
+// logged, read from or written to disk unless the options ask for it: the
+// newsletter-cli settings, audit log and config directory are not used.
+// Options and UnsubscribeOptions take a logger, unsubscribe templates and
+// hooks to persist batch sizes and unsubscribe results.
+package newsletter
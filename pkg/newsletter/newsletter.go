@@ -0,0 +1,211 @@
+package newsletter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/loickal/newsletter-cli/internal/imap"
+)
+
+// ErrNoEmails is returned by Analyze when the search window contains no
+// messages
+var ErrNoEmails = imap.ErrNoEmails
+
+// Account holds the credentials of an IMAP account
+type Account struct {
+	Email    string
+	Password string // Usually an app password
+	Server   string // host:port, discovered from the email address when empty
+}
+
+// Logger receives progress messages. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// BatchSizeStore keeps the FETCH batch size learned for each server, so
+// later analyses start from it
+type BatchSizeStore interface {
+	// BatchSize returns the size last saved for server, 0 if none
+	BatchSize(server string) int
+	SaveBatchSize(server string, size int) error
+}
+
+// Options configures an analysis. The zero value analyzes INBOX over the
+// whole mailbox, downloads every message in full, logs nothing and writes
+// nothing to disk.
+type Options struct {
+	// Since is the date to search from. The zero time searches the whole
+	// mailbox unless Window is set.
+	Since time.Time
+	// Window is a period such as "30", "90d", "2w", "3m", "1y" or "all",
+	// used instead of Since when set
+	Window string
+	// Folders to analyze, INBOX when empty
+	Folders []string
+	// MaxMessageSizeMB analyzes larger messages from their headers only,
+	// skipping large attachments. 0 downloads every message.
+	MaxMessageSizeMB int
+	// SkipBodyScan fetches only headers, giving up preference link and
+	// tracking detection
+	SkipBodyScan bool
+	// Logger receives progress messages, discarded when nil
+	Logger Logger
+	// BatchSizes keeps learned batch sizes between analyses, nil learns
+	// them anew on every call
+	BatchSizes BatchSizeStore
+}
+
+// Newsletter is a sender found to be sending mailing-list mail
+type Newsletter struct {
+	Sender        string // Lowercased address, the same whatever the case of the From header
+	Name          string // Display name from the From header, if any
+	Domain        string // Domain of the sender address
+	Brand         string // Groups the addresses of one organization, see Report.ByBrand
+	Count         int    // Messages in the search window
+	Unsubscribe   string // Unsubscribe link, HTTP(S) or mailto:, if any
+	Preferences   string // Preference center link, to reduce frequency instead
+	Transactional bool   // Most messages look like receipts, resets or security alerts
+
+	// Messages whose body was scanned, and how many of those carried an
+	// open-tracking pixel or click-tracking links
+	ScannedEmails int
+	TrackedEmails int
+
+	// Engagement, from the IMAP flags: messages marked as read, answered
+	// and flagged (starred)
+	ReadEmails    int
+	RepliedEmails int
+	StarredEmails int
+
+	// Arrival histograms in local time: messages per hour of day and per
+	// weekday (index 0 is Sunday, as in time.Weekday)
+	ByHour    [24]int
+	ByWeekday [7]int
+}
+
+// Folder summarizes what was found in a single analyzed folder
+type Folder struct {
+	Name             string
+	Emails           int   // Messages in the search window
+	Newsletters      int   // Distinct newsletter senders
+	NewsletterEmails int   // Messages from newsletter senders
+	LargeMessages    int   // Messages above the size limit, analyzed from headers only
+	Err              error // Set when the folder could not be analyzed
+}
+
+// Report is the result of an analysis
+type Report struct {
+	Newsletters []Newsletter // Merged across folders
+	Folders     []Folder
+}
+
+// ByBrand groups the newsletters by Brand, such as the several addresses a
+// single shop sends from
+func (r *Report) ByBrand() map[string][]Newsletter {
+	brands := make(map[string][]Newsletter)
+	for _, n := range r.Newsletters {
+		brands[n.Brand] = append(brands[n.Brand], n)
+	}
+	return brands
+}
+
+// DiscoverServer finds the IMAP server of an email address from known
+// providers and DNS autodiscovery
+func DiscoverServer(email string) (string, error) {
+	return imap.DiscoverServer(email, nil)
+}
+
+// Connect checks that the account can log in
+func Connect(account Account) error {
+	account, err := resolveServer(account)
+	if err != nil {
+		return err
+	}
+	return imap.CheckLogin(account.Email, account.Password, account.Server, nil)
+}
+
+// ParseWindow converts a period such as "30", "90d", "2w", "3m", "1y" or
+// "all" into the date to search from. "all" returns the zero time.
+func ParseWindow(window string) (time.Time, error) {
+	return imap.ParseSearchWindow(window)
+}
+
+// Analyze scans the account for newsletters
+func Analyze(account Account, opts Options) (*Report, error) {
+	account, err := resolveServer(account)
+	if err != nil {
+		return nil, err
+	}
+
+	since := opts.Since
+	if opts.Window != "" {
+		if since, err = imap.ParseSearchWindow(opts.Window); err != nil {
+			return nil, err
+		}
+	}
+	fetch := imap.FetchOptions{
+		Folders:      opts.Folders,
+		SkipBodyScan: opts.SkipBodyScan,
+		Logger:       opts.Logger,
+		BatchSizes:   opts.BatchSizes,
+	}
+	if opts.MaxMessageSizeMB > 0 && opts.MaxMessageSizeMB < 4096 {
+		fetch.MaxMessageSize = uint32(opts.MaxMessageSizeMB) << 20
+	}
+
+	stats, folderStats, err := imap.FetchFolderStatsWith(account.Server, account.Email, account.Password, since, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Newsletters: make([]Newsletter, 0, len(stats)),
+		Folders:     make([]Folder, 0, len(folderStats)),
+	}
+	for _, s := range stats {
+		report.Newsletters = append(report.Newsletters, Newsletter{
+			Sender:        s.Sender.Address,
+			Name:          s.Sender.Name,
+			Domain:        s.Sender.Domain,
+			Brand:         s.Sender.Brand,
+			Count:         s.Count,
+			Unsubscribe:   s.Unsubscribe,
+			Preferences:   s.Preferences,
+			Transactional: s.Transactional,
+			ScannedEmails: s.ScannedEmails,
+			TrackedEmails: s.TrackedEmails,
+			ReadEmails:    s.ReadEmails,
+			RepliedEmails: s.RepliedEmails,
+			StarredEmails: s.StarredEmails,
+			ByHour:        s.ByHour,
+			ByWeekday:     s.ByWeekday,
+		})
+	}
+	for _, f := range folderStats {
+		report.Folders = append(report.Folders, Folder{
+			Name:             f.Name,
+			Emails:           f.Emails,
+			Newsletters:      f.Newsletters,
+			NewsletterEmails: f.NewsletterEmails,
+			LargeMessages:    f.LargeMessages,
+			Err:              f.Err,
+		})
+	}
+	return report, nil
+}
+
+// resolveServer fills in the IMAP server of an account when it is missing
+func resolveServer(account Account) (Account, error) {
+	if account.Email == "" {
+		return account, errors.New("account email is required")
+	}
+	if account.Server == "" {
+		server, err := imap.DiscoverServer(account.Email, nil)
+		if err != nil {
+			return account, err
+		}
+		account.Server = server
+	}
+	return account, nil
+}
@@ -0,0 +1,144 @@
+package newsletter
+
+import (
+	"github.com/loickal/newsletter-cli/internal/config"
+	"github.com/loickal/newsletter-cli/internal/unsubscribe"
+)
+
+// Request is a newsletter to unsubscribe from
+type Request struct {
+	Sender string
+	Link   string // Unsubscribe link, HTTP(S) or mailto:
+}
+
+// Result is the outcome of an unsubscribe
+type Result struct {
+	Sender   string
+	Link     string
+	Success  bool
+	Error    string // Why the unsubscribe failed
+	Method   string // How the unsubscribe was performed, set on success
+	Provider string // Domain the unsubscribe link points at
+	Paused   bool   // Not attempted because the provider appears to be down
+}
+
+// MailTemplate is the email sent for a mailto: unsubscribe link. The
+// placeholders {{sender}}, {{account}} and {{date}} are filled in, and
+// empty fields fall back to the built-in template.
+type MailTemplate struct {
+	Subject string
+	Body    string
+}
+
+// UnsubscribeOptions configures UnsubscribeWith and UnsubscribeAllWith. The
+// zero value sends the built-in English template for mailto: links, stores
+// nothing in the mailbox and writes nothing to disk.
+type UnsubscribeOptions struct {
+	// Language of the built-in template, such as "de", English when empty
+	Language string
+	// Templates by language code, overriding the built-in ones
+	Templates map[string]MailTemplate
+	// ProviderTemplates by unsubscribe address domain, overriding both
+	ProviderTemplates map[string]MailTemplate
+	// NotesFolder is the mailbox folder a note is stored in for each
+	// successful unsubscribe, none when empty
+	NotesFolder string
+	// OnResult is called with the outcome of every attempt, for example to
+	// keep an audit log
+	OnResult func(Result)
+}
+
+// Unsubscribe unsubscribes from one newsletter. HTTP links use RFC 8058
+// one-click POST with a GET fallback; mailto: links are sent over the
+// account's SMTP server.
+func Unsubscribe(account Account, sender, link string) Result {
+	return UnsubscribeWith(account, sender, link, UnsubscribeOptions{})
+}
+
+// UnsubscribeWith is Unsubscribe with explicit options
+func UnsubscribeWith(account Account, sender, link string, opts UnsubscribeOptions) Result {
+	account, err := resolveServer(account)
+	if err != nil {
+		return opts.report(Result{Sender: sender, Link: link, Error: err.Error()})
+	}
+	r := unsubscribe.UnsubscribeWith(sender, link, account.Email, account.Password, account.Server, opts.internal())
+	return opts.report(newResult(r))
+}
+
+// UnsubscribeAll unsubscribes from several newsletters concurrently.
+// Requests to a provider that keeps failing are paused and returned with
+// Paused set, so they can be retried later.
+func UnsubscribeAll(account Account, requests []Request) []Result {
+	return UnsubscribeAllWith(account, requests, UnsubscribeOptions{})
+}
+
+// UnsubscribeAllWith is UnsubscribeAll with explicit options
+func UnsubscribeAllWith(account Account, requests []Request, opts UnsubscribeOptions) []Result {
+	account, err := resolveServer(account)
+	if err != nil {
+		results := make([]Result, 0, len(requests))
+		for _, req := range requests {
+			results = append(results, opts.report(Result{Sender: req.Sender, Link: req.Link, Error: err.Error()}))
+		}
+		return results
+	}
+
+	batch := make([]struct {
+		Sender string
+		Link   string
+	}, 0, len(requests))
+	for _, req := range requests {
+		batch = append(batch, struct {
+			Sender string
+			Link   string
+		}{req.Sender, req.Link})
+	}
+
+	results := make([]Result, 0, len(requests))
+	for _, r := range unsubscribe.BatchUnsubscribeWith(batch, account.Email, account.Password, account.Server, opts.internal()) {
+		results = append(results, opts.report(newResult(r)))
+	}
+	return results
+}
+
+// internal converts the options for the unsubscribe engine
+func (o UnsubscribeOptions) internal() unsubscribe.Options {
+	opts := unsubscribe.Options{
+		Templates: config.UnsubscribeTemplates{Language: o.Language},
+		Notes:     config.UnsubscribeNotes{Enabled: o.NotesFolder != "", Folder: o.NotesFolder},
+	}
+	if len(o.Templates) > 0 {
+		opts.Templates.Languages = make(map[string]config.MailTemplate, len(o.Templates))
+		for lang, t := range o.Templates {
+			opts.Templates.Languages[lang] = config.MailTemplate{Subject: t.Subject, Body: t.Body}
+		}
+	}
+	if len(o.ProviderTemplates) > 0 {
+		opts.Templates.Providers = make(map[string]config.MailTemplate, len(o.ProviderTemplates))
+		for domain, t := range o.ProviderTemplates {
+			opts.Templates.Providers[domain] = config.MailTemplate{Subject: t.Subject, Body: t.Body}
+		}
+	}
+	return opts
+}
+
+// report passes a result to OnResult, if set, and returns it
+func (o UnsubscribeOptions) report(r Result) Result {
+	if o.OnResult != nil {
+		o.OnResult(r)
+	}
+	return r
+}
+
+// newResult converts an internal unsubscribe result
+func newResult(r unsubscribe.UnsubscribeResult) Result {
+	return Result{
+		Sender:   r.Sender,
+		Link:     r.Link,
+		Success:  r.Success,
+		Error:    r.ErrorMsg,
+		Method:   r.Method,
+		Provider: r.Provider,
+		Paused:   r.Paused,
+	}
+}